@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/subjectaccessreview"
+	"github.com/fi-ts/gardener-extension-authn/pkg/tokenreview"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The configuration is read from the same environment variables as the ones of the kubernetes-authn-webhook image,
+// such that both images can be used interchangeably by the extension.
+const (
+	envListen               = "LISTEN"
+	envMetricsListen        = "METRICS_LISTEN"
+	envIssuer               = "ISSUER"
+	envClientID             = "CLIENTID"
+	envJWKSURL              = "JWKS_URL"
+	envGroupsPrefixToRemove = "GROUPSPREFIXTOREMOVE"
+	envTenant               = "TENANT"
+	envProviderTenant       = "PROVIDERTENANT"
+	envAdditionalTenants    = "ADDITIONAL_TENANTS"
+	envCluster              = "CLUSTER"
+	envProject              = "PROJECT"
+	envAccessScope          = "ACCESS_SCOPE"
+	envUsernameClaim        = "USERNAME_CLAIM"
+	envGroupsClaim          = "GROUPS_CLAIM"
+	envTenantClaim          = "TENANT_CLAIM"
+	envGroupResolver        = "GROUP_RESOLVER"
+	envStaticGroupsFile     = "STATIC_GROUPS_FILE"
+	envMetalURL             = "METAL_URL"
+	envMetalHMAC            = "METAL_HMAC"
+	envMetalHMACAuthType    = "METAL_HMACAUTHTYPE"
+	envMetalV2URL           = "METAL_V2_URL"
+	envMetalV2Token         = "METAL_V2_TOKEN"
+	envTimeout              = "TIMEOUT"
+	envRetries              = "RETRIES"
+	envRetryInitialDelay    = "RETRY_INITIAL_DELAY"
+	envAuthzProviderDeny    = "AUTHZ_PROVIDER_TENANT_DENY"
+	envBreakGlassTokenFile  = "BREAK_GLASS_TOKEN_FILE"
+	envSuspended            = "SUSPENDED"
+	envExtensionName        = "EXTENSION_NAME"
+	envPodNamespace         = "POD_NAMESPACE"
+)
+
+const accessScopeProject = "Project"
+
+const (
+	groupResolverMetalAPI = "metal-api"
+	groupResolverMetalV2  = "metal-v2"
+	groupResolverStatic   = "static"
+	groupResolverNone     = "none"
+)
+
+func main() {
+	log.SetLogger(zap.New())
+	logger := log.Log.WithName("authn-webhook")
+
+	ctx := signals.SetupSignalHandler()
+
+	if len(os.Args) > 1 && os.Args[1] == waitCommand {
+		if err := wait(ctx, logger, os.Args[2:]); err != nil {
+			logger.Error(err, "error waiting for authn webhook")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(ctx); err != nil {
+		logger.Error(err, "error running authn webhook")
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	logger := log.Log.WithName("authn-webhook")
+
+	config := tokenreview.Config{
+		Issuer:               os.Getenv(envIssuer),
+		ClientID:             os.Getenv(envClientID),
+		Tenant:               os.Getenv(envTenant),
+		ProviderTenant:       os.Getenv(envProviderTenant),
+		AdditionalTenants:    splitList(os.Getenv(envAdditionalTenants)),
+		Cluster:              os.Getenv(envCluster),
+		Project:              os.Getenv(envProject),
+		ProjectScoped:        os.Getenv(envAccessScope) == accessScopeProject,
+		GroupsPrefixToRemove: os.Getenv(envGroupsPrefixToRemove),
+		ClaimMapping: tokenreview.ClaimMapping{
+			Username: os.Getenv(envUsernameClaim),
+			Groups:   os.Getenv(envGroupsClaim),
+			Tenant:   os.Getenv(envTenantClaim),
+		},
+	}
+
+	timeout, err := time.ParseDuration(getEnv(envTimeout, "10s"))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envTimeout, err)
+	}
+	config.Timeout = timeout
+
+	retries, err := strconv.Atoi(getEnv(envRetries, "0"))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envRetries, err)
+	}
+
+	retryInitialDelay, err := time.ParseDuration(getEnv(envRetryInitialDelay, "500ms"))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envRetryInitialDelay, err)
+	}
+
+	suspended, err := strconv.ParseBool(getEnv(envSuspended, "false"))
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", envSuspended, err)
+	}
+	config.Suspended = suspended
+
+	if config.ProjectScoped && config.Project == "" {
+		return fmt.Errorf("environment variable %s must be set for project scoped access", envProject)
+	}
+
+	for env, value := range map[string]string{
+		envIssuer:   config.Issuer,
+		envClientID: config.ClientID,
+		envTenant:   config.Tenant,
+		envCluster:  config.Cluster,
+	} {
+		if value == "" {
+			return fmt.Errorf("environment variable %s must be set", env)
+		}
+	}
+
+	resolver, err := newGroupResolver(config)
+	if err != nil {
+		return err
+	}
+
+	resolver = tokenreview.NewRetryResolver(logger, resolver, retries, retryInitialDelay)
+
+	authenticator, err := tokenreview.NewAuthenticator(logger, config, tokenreview.NewOIDCVerifier(config.Issuer, config.ClientID, os.Getenv(envJWKSURL)), resolver)
+	if err != nil {
+		return err
+	}
+
+	if path := os.Getenv(envBreakGlassTokenFile); path != "" {
+		breakGlass, err := newBreakGlass(ctx, logger, path)
+		if err != nil {
+			return err
+		}
+		authenticator.WithBreakGlass(breakGlass)
+	}
+
+	authorizerConfig := subjectaccessreview.Config{
+		ProviderTenant: config.ProviderTenant,
+	}
+	if deny := os.Getenv(envAuthzProviderDeny); deny != "" {
+		if err := json.Unmarshal([]byte(deny), &authorizerConfig.ProviderTenantDeny); err != nil {
+			return fmt.Errorf("invalid %s: %w", envAuthzProviderDeny, err)
+		}
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewGoCollector(), prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	if err := tokenreview.RegisterMetrics(reg); err != nil {
+		return err
+	}
+	if err := subjectaccessreview.RegisterMetrics(reg); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/authenticate", tokenreview.NewHandler(logger, authenticator))
+	mux.Handle("/authorize", subjectaccessreview.NewHandler(logger, subjectaccessreview.NewAuthorizer(authorizerConfig)))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	servers := []*http.Server{
+		{Addr: getEnv(envListen, ":8443"), Handler: mux, ReadHeaderTimeout: 10 * time.Second},
+		{Addr: getEnv(envMetricsListen, ":2112"), Handler: metricsMux, ReadHeaderTimeout: 10 * time.Second},
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, srv := range servers {
+		g.Go(func() error {
+			logger.Info("starting server", "address", srv.Addr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		for _, srv := range servers {
+			_ = srv.Shutdown(shutdownCtx)
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// newBreakGlass returns the break-glass authenticator, every use of an emergency token is recorded as an event
+// of the extension resource.
+func newBreakGlass(ctx context.Context, logger logr.Logger, path string) (*tokenreview.BreakGlass, error) {
+	namespace, extensionName := os.Getenv(envPodNamespace), os.Getenv(envExtensionName)
+	if namespace == "" || extensionName == "" {
+		return nil, fmt.Errorf("environment variables %s and %s must be set for break-glass tokens", envPodNamespace, envExtensionName)
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	broadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events(namespace)})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "authn-webhook"})
+
+	extension := &corev1.ObjectReference{
+		APIVersion: extensionsv1alpha1.SchemeGroupVersion.String(),
+		Kind:       extensionsv1alpha1.ExtensionResource,
+		Namespace:  namespace,
+		Name:       extensionName,
+	}
+
+	return tokenreview.NewBreakGlass(path, func(user *tokenreview.User) {
+		logger.Info("break-glass token used", "user", user.Name)
+		recorder.Eventf(extension, corev1.EventTypeWarning, "BreakGlassCredentialsUsed", "Break-glass credentials of user %s were used to authenticate", user.Name)
+	})
+}
+
+func newGroupResolver(config tokenreview.Config) (tokenreview.GroupResolver, error) {
+	resolver := os.Getenv(envGroupResolver)
+	if resolver == "" {
+		resolver = groupResolverNone
+		if os.Getenv(envMetalURL) != "" {
+			resolver = groupResolverMetalAPI
+		}
+	}
+
+	switch resolver {
+	case groupResolverMetalAPI:
+		return tokenreview.NewMetalAPIResolver(tokenreview.MetalAPIConfig{
+			URL:      os.Getenv(envMetalURL),
+			HMAC:     os.Getenv(envMetalHMAC),
+			AuthType: os.Getenv(envMetalHMACAuthType),
+		}, config.Tenant, config.Cluster, config.ProviderTenant)
+	case groupResolverMetalV2:
+		return tokenreview.NewMetalV2Resolver(tokenreview.MetalV2Config{
+			URL:   os.Getenv(envMetalV2URL),
+			Token: os.Getenv(envMetalV2Token),
+		}, config.Tenant, config.Cluster, config.ProviderTenant)
+	case groupResolverStatic:
+		return tokenreview.NewStaticResolver(os.Getenv(envStaticGroupsFile))
+	case groupResolverNone:
+		return tokenreview.NewNoopResolver(), nil
+	default:
+		return nil, fmt.Errorf("unsupported group resolver %q", resolver)
+	}
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func getEnv(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// waitCommand is the argument that runs the authn webhook as init container of the kube-apiserver, which waits
+// until the webhook is ready before the kube-apiserver starts.
+const waitCommand = "wait"
+
+// wait polls the health endpoint of the webhook given by the arguments until it responds successfully. It always
+// returns without error once the timeout is exceeded, such that the kube-apiserver starts in any case.
+func wait(ctx context.Context, logger logr.Logger, args []string) error {
+	flags := flag.NewFlagSet(waitCommand, flag.ContinueOnError)
+	url := flags.String("url", "", "url of the health endpoint of the webhook")
+	timeout := flags.Duration("timeout", 2*time.Minute, "maximum duration to wait for the webhook")
+	interval := flags.Duration("interval", time.Second, "interval in which the webhook is polled")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *url == "" {
+		return fmt.Errorf("flag --url must be set")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	for {
+		err := checkHealthz(ctx, client, *url)
+		if err == nil {
+			logger.Info("authn webhook is ready", "url", *url)
+			return nil
+		}
+		logger.V(1).Info("authn webhook is not ready yet", "url", *url, "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			logger.Info("authn webhook is not ready after the timeout, starting kube-apiserver anyway", "url", *url, "timeout", timeout.String())
+			return nil
+		case <-time.After(*interval):
+		}
+	}
+}
+
+func checkHealthz(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name         string
+		readyAfter   int32
+		status       int
+		timeout      string
+		wantRequests int32
+	}{
+		{name: "ready", status: http.StatusOK, timeout: "1s", wantRequests: 1},
+		{name: "ready after some requests", readyAfter: 2, status: http.StatusOK, timeout: "1s", wantRequests: 3},
+		{name: "never ready", readyAfter: 1000, status: http.StatusOK, timeout: "50ms"},
+		{name: "unhealthy", status: http.StatusInternalServerError, timeout: "50ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/healthz" {
+					http.NotFound(w, r)
+					return
+				}
+				if requests.Add(1) <= tt.readyAfter {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			start := time.Now()
+			if err := wait(context.Background(), logr.Discard(), []string{"--url=" + server.URL + "/healthz", "--timeout=" + tt.timeout, "--interval=10ms"}); err != nil {
+				t.Fatalf("wait() error = %v", err)
+			}
+			if time.Since(start) > 5*time.Second {
+				t.Errorf("wait() did not return after the timeout")
+			}
+			if tt.wantRequests > 0 && requests.Load() != tt.wantRequests {
+				t.Errorf("webhook was polled %d times, want %d", requests.Load(), tt.wantRequests)
+			}
+		})
+	}
+
+	t.Run("missing url", func(t *testing.T) {
+		if err := wait(context.Background(), logr.Discard(), nil); err == nil {
+			t.Error("wait() expected error")
+		}
+	})
+}
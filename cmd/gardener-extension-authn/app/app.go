@@ -7,6 +7,7 @@ import (
 
 	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/install"
 	"github.com/fi-ts/gardener-extension-authn/pkg/controller"
+	"github.com/fi-ts/gardener-extension-authn/pkg/webhook/kapiserver"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	heartbeatcontroller "github.com/gardener/gardener/extensions/pkg/controller/heartbeat"
@@ -20,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 const GardenKubeconfigEnvName = "GARDEN_KUBECONFIG"
@@ -104,13 +106,23 @@ func (o *Options) run(ctx context.Context) error {
 		return fmt.Errorf("failed adding garden cluster to manager: %w", err)
 	}
 
+	if err := controller.RegisterMetrics(metrics.Registry); err != nil {
+		return fmt.Errorf("could not register controller metrics: %w", err)
+	}
+
+	if err := kapiserver.RegisterMetrics(metrics.Registry); err != nil {
+		return fmt.Errorf("could not register webhook metrics: %w", err)
+	}
+
 	log.Info("Adding controllers to manager")
 
 	ctrlConfig := o.authnOptions.Completed()
 	ctrlConfig.Apply(&controller.DefaultAddOptions.Config)
+	ctrlConfig.Apply(&kapiserver.DefaultAddOptions.Config)
 	o.controllerOptions.Completed().Apply(&controller.DefaultAddOptions.ControllerOptions)
 	o.reconcileOptions.Completed().Apply(&controller.DefaultAddOptions.IgnoreOperationAnnotation, &controller.DefaultAddOptions.ExtensionClass)
 	o.heartbeatOptions.Completed().Apply(&heartbeatcontroller.DefaultAddOptions)
+	controller.DefaultAddOptions.GardenCluster = gardenCluster
 
 	if err := o.controllerSwitches.Completed().AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("could not add controllers to manager: %w", err)
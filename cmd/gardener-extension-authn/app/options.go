@@ -13,7 +13,7 @@ import (
 // ExtensionName is the name of the extension.
 const ExtensionName = "extension-fits-authn"
 
-// Options holds configuration passed to the registry service controller.
+// Options holds configuration passed to the fits-authn controller.
 type Options struct {
 	generalOptions     *controllercmd.GeneralOptions
 	authnOptions       *authncmd.AuthOptions
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/grouprolebinding"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+func main() {
+	var (
+		opts              grouprolebinding.Options
+		kubeconfig        string
+		metricsAddr       string
+		additionalTenants []string
+	)
+
+	fs := pflag.NewFlagSet(grouprolebinding.Name, pflag.ExitOnError)
+	fs.StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig of the cluster in which the rolebindings are managed")
+	fs.StringVar(&opts.ClusterName, "clustername", "", "name of the cluster, used as part of the group names")
+	fs.StringSliceVar(&opts.ExcludeNamespaces, "excludeNamespaces", grouprolebinding.DefaultExcludeNamespaces, "namespaces in which no rolebindings are created")
+	fs.StringSliceVar(&opts.ExpectedGroups, "expectedGroupsList", grouprolebinding.DefaultExpectedGroups, "cluster roles that are bound to a group in every namespace")
+	fs.StringArrayVar(&additionalTenants, "additionalTenant", nil, "roles of an additional tenant in the format <tenant>=<role>:<clusterrole>,..., can be given multiple times")
+	fs.StringVar(&metricsAddr, "metrics-bind-address", ":2112", "address the metrics endpoint binds to")
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	log.SetLogger(zap.New())
+	logger := log.Log.WithName(grouprolebinding.Name)
+
+	if opts.ClusterName == "" {
+		logger.Error(fmt.Errorf("clustername must be set"), "invalid arguments")
+		os.Exit(1)
+	}
+
+	for _, t := range additionalTenants {
+		tenant, err := grouprolebinding.ParseTenantRoles(t)
+		if err != nil {
+			logger.Error(err, "invalid arguments")
+			os.Exit(1)
+		}
+		opts.AdditionalTenants = append(opts.AdditionalTenants, tenant)
+	}
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		logger.Error(err, "unable to create rest config")
+		os.Exit(1)
+	}
+
+	mgr, err := manager.New(restConfig, manager.Options{
+		Metrics: metricsserver.Options{
+			BindAddress: metricsAddr,
+		},
+	})
+	if err != nil {
+		logger.Error(err, "unable to create manager")
+		os.Exit(1)
+	}
+
+	if err := grouprolebinding.AddToManager(mgr, opts); err != nil {
+		logger.Error(err, "unable to add controller to manager")
+		os.Exit(1)
+	}
+
+	logger.Info("starting controller", "cluster", opts.ClusterName)
+
+	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+		logger.Error(err, "error running manager")
+		os.Exit(1)
+	}
+}
@@ -30,8 +30,9 @@ var (
 
 // Adds the list of known types to api.Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
-	// scheme.AddKnownTypes(SchemeGroupVersion,
-	// 	&AuthnConfig{},
-	// )
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&AuthnConfig{},
+		&AuthnStatus{},
+	)
 	return nil
 }
@@ -12,4 +12,107 @@ type AuthnConfig struct {
 
 	Issuer   string
 	ClientID string
+
+	// Profile is the name of the auth profile of the controller configuration the cluster uses.
+	Profile string
+
+	// AccessScope defines which users of the tenant are allowed to access the cluster.
+	AccessScope AccessScope
+
+	// AdditionalTenants are tenants besides the owning tenant whose members may access the cluster.
+	AdditionalTenants []TenantAccess
+
+	// TokenWebhook contains the settings of the token webhook that override the ones of the controller configuration.
+	TokenWebhook *TokenWebhook
+
+	// Suspended stops the authentication with the token webhook while keeping the RBAC objects in the cluster.
+	// If break-glass credentials are issued, the webhook keeps running and only accepts them.
+	Suspended bool
+}
+
+// TenantAccess grants the members of an additional tenant access to the cluster.
+type TenantAccess struct {
+	// Name is the name of the tenant.
+	Name string
+	// RoleMapping maps the roles of the tenant's groups to the cluster roles that are bound in the namespaces.
+	RoleMapping map[string]string
+}
+
+// AccessScope defines which users of the tenant are allowed to access the cluster.
+type AccessScope string
+
+const (
+	// AccessScopeTenant allows all members of the tenant to access the cluster.
+	AccessScopeTenant AccessScope = "Tenant"
+	// AccessScopeProject only allows members of the metal project the cluster belongs to.
+	AccessScopeProject AccessScope = "Project"
+)
+
+// TokenWebhook contains the settings of the token webhook.
+type TokenWebhook struct {
+	// CacheTTL is the duration for which the kube-apiserver caches the responses of the token webhook.
+	CacheTTL *metav1.Duration
+	// Timeout is the timeout for authenticating a token including the requests to the issuer and the membership backend.
+	Timeout *metav1.Duration
+	// RetryBackoff defines how failed requests to the membership backend are retried.
+	RetryBackoff *RetryBackoff
+}
+
+// RetryBackoff defines how failed requests are retried.
+type RetryBackoff struct {
+	// InitialDelay is the delay before the first retry, it is doubled for every further retry.
+	InitialDelay *metav1.Duration
+	// Retries is the maximum number of retries.
+	Retries *int32
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthnStatus contains the authentication settings that are in effect for the cluster.
+type AuthnStatus struct {
+	metav1.TypeMeta
+
+	// Issuers are the issuers whose tokens the kube-apiserver accepts.
+	Issuers []string
+	// Tenant is the tenant that owns the cluster.
+	Tenant string
+	// Profile is the name of the auth profile the cluster uses.
+	Profile string
+	// Images are the images of the deployed authn components.
+	Images []ComponentImage
+	// Webhook describes how the kube-apiserver is connected to the token webhook.
+	Webhook WebhookStatus
+	// ConfigHash is the hash of the rendered configuration of the authn components.
+	ConfigHash string
+	// InputHash is the hash of the inputs the configuration was rendered from.
+	InputHash string
+}
+
+// ComponentImage is the image of a deployed component.
+type ComponentImage struct {
+	// Name is the name of the component.
+	Name string
+	// Image is the image reference of the component.
+	Image string
+}
+
+// WebhookStatus describes how the kube-apiserver is connected to the token webhook.
+type WebhookStatus struct {
+	// Endpoint is the url the kube-apiserver sends token reviews to.
+	Endpoint string
+	// Mode is the authentication mode of the kube-apiserver.
+	Mode AuthenticationMode
+	// Suspended is true if the authentication with the token webhook is suspended.
+	Suspended bool
+}
+
+// AuthenticationMode describes how the kube-apiserver authenticates tokens.
+type AuthenticationMode string
+
+const (
+	// AuthenticationModeWebhook authenticates tokens with the token webhook only.
+	AuthenticationModeWebhook AuthenticationMode = "Webhook"
+	// AuthenticationModeStructured authenticates tokens with the token webhook and the structured or oidc
+	// authentication that gardener configures for the shoot.
+	AuthenticationModeStructured AuthenticationMode = "Structured"
+)
@@ -7,3 +7,10 @@ import (
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_AuthnConfig sets the defaults for the authn configuration.
+func SetDefaults_AuthnConfig(cfg *AuthnConfig) {
+	if cfg.AccessScope == "" {
+		cfg.AccessScope = AccessScopeTenant
+	}
+}
@@ -9,6 +9,14 @@ const (
 	ShootAuthResourceName = "extension-fits-auth-shoot"
 )
 
+const (
+	// AnnotationOperation is the annotation of the Extension resource that triggers operations of the extension.
+	// It is removed once the operation was carried out.
+	AnnotationOperation = "authn.fits.cloud/operation"
+	// OperationRotateBreakGlassCredentials rotates the break-glass credentials of the shoot.
+	OperationRotateBreakGlassCredentials = "rotate-break-glass-credentials"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // AuthnConfig configuration resource
@@ -17,4 +25,137 @@ type AuthnConfig struct {
 
 	Issuer   string `json:"issuer,omitempty"`
 	ClientID string `json:"clientID,omitempty"`
+
+	// Profile is the name of the auth profile of the controller configuration the cluster uses.
+	// If not set, the profile is selected by the tenant of the cluster or the default profile is used.
+	// The issuer and client id default to the ones of the profile.
+	// +optional
+	Profile string `json:"profile,omitempty"`
+
+	// AccessScope defines which users of the tenant are allowed to access the cluster, defaults to Tenant.
+	// The Project scope requires the shoot to carry the metal project annotation and the fits-authn-webhook.
+	// +optional
+	AccessScope AccessScope `json:"accessScope,omitempty"`
+
+	// AdditionalTenants are tenants besides the owning tenant whose members may access the cluster.
+	// The access scope only applies to the members of the owning tenant. Requires the fits-authn-webhook.
+	// +optional
+	AdditionalTenants []TenantAccess `json:"additionalTenants,omitempty"`
+
+	// TokenWebhook contains the settings of the token webhook that override the ones of the controller configuration.
+	// +optional
+	TokenWebhook *TokenWebhook `json:"tokenWebhook,omitempty"`
+
+	// Suspended stops the authentication with the token webhook, e.g. during incidents of the issuer.
+	// The kube-apiserver is configured without the webhook and the webhook is scaled to zero, the RBAC objects
+	// of the cluster are kept such that access is restored as before once the suspension is lifted.
+	// If break-glass credentials are issued, the webhook keeps running and only accepts them.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+// TenantAccess grants the members of an additional tenant access to the cluster.
+type TenantAccess struct {
+	// Name is the name of the tenant.
+	Name string `json:"name"`
+	// RoleMapping maps the roles of the tenant's groups to the cluster roles that are bound in the namespaces,
+	// e.g. "admin: edit". Groups of roles that are not contained do not grant any permissions.
+	RoleMapping map[string]string `json:"roleMapping"`
+}
+
+// AccessScope defines which users of the tenant are allowed to access the cluster.
+type AccessScope string
+
+const (
+	// AccessScopeTenant allows all members of the tenant to access the cluster.
+	AccessScopeTenant AccessScope = "Tenant"
+	// AccessScopeProject only allows members of the metal project the cluster belongs to.
+	AccessScopeProject AccessScope = "Project"
+)
+
+// TokenWebhook contains the settings of the token webhook.
+type TokenWebhook struct {
+	// CacheTTL is the duration for which the kube-apiserver caches the responses of the token webhook.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+	// Timeout is the timeout for authenticating a token including the requests to the issuer and the membership backend.
+	// The kube-apiserver gives up on the webhook after 30s, so the timeout must not exceed it.
+	// Requires the fits-authn-webhook.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// RetryBackoff defines how failed requests to the membership backend are retried.
+	// Requires the fits-authn-webhook.
+	// +optional
+	RetryBackoff *RetryBackoff `json:"retryBackoff,omitempty"`
 }
+
+// RetryBackoff defines how failed requests are retried.
+type RetryBackoff struct {
+	// InitialDelay is the delay before the first retry, it is doubled for every further retry.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+	// Retries is the maximum number of retries.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthnStatus contains the authentication settings that are in effect for the cluster.
+// It is written to the provider status of the Extension resource whenever the inputs of the reconciliation change.
+type AuthnStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Issuers are the issuers whose tokens the kube-apiserver accepts. The issuer of the token webhook comes
+	// first, followed by the issuers of the native authentication of the shoot.
+	// +optional
+	Issuers []string `json:"issuers,omitempty"`
+	// Tenant is the tenant that owns the cluster.
+	Tenant string `json:"tenant"`
+	// Profile is the name of the auth profile the cluster uses, "default" for the auth configuration
+	// of the controller.
+	Profile string `json:"profile"`
+	// Images are the images of the deployed authn components.
+	// +optional
+	Images []ComponentImage `json:"images,omitempty"`
+	// Webhook describes how the kube-apiserver is connected to the token webhook.
+	Webhook WebhookStatus `json:"webhook"`
+	// ConfigHash is the hash of the rendered configuration of the authn components, it changes whenever
+	// the deployed objects change.
+	ConfigHash string `json:"configHash"`
+	// InputHash is the hash of the inputs the configuration was rendered from. The reconciliation skips
+	// rewriting the managed resources as long as it is unchanged and the managed resources are healthy. It is
+	// empty for development builds, whose images are not pinned.
+	// +optional
+	InputHash string `json:"inputHash,omitempty"`
+}
+
+// ComponentImage is the image of a deployed component.
+type ComponentImage struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+	// Image is the image reference of the component.
+	Image string `json:"image"`
+}
+
+// WebhookStatus describes how the kube-apiserver is connected to the token webhook.
+type WebhookStatus struct {
+	// Endpoint is the url the kube-apiserver sends token reviews to.
+	Endpoint string `json:"endpoint"`
+	// Mode is the authentication mode of the kube-apiserver.
+	Mode AuthenticationMode `json:"mode"`
+	// Suspended is true if the authentication with the token webhook is suspended.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+}
+
+// AuthenticationMode describes how the kube-apiserver authenticates tokens.
+type AuthenticationMode string
+
+const (
+	// AuthenticationModeWebhook authenticates tokens with the token webhook only.
+	AuthenticationModeWebhook AuthenticationMode = "Webhook"
+	// AuthenticationModeStructured authenticates tokens with the token webhook and the structured or oidc
+	// authentication that gardener configures for the shoot.
+	AuthenticationModeStructured AuthenticationMode = "Structured"
+)
@@ -10,7 +10,10 @@
 package v1alpha1
 
 import (
+	unsafe "unsafe"
+
 	authn "github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -32,12 +35,77 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AuthnStatus)(nil), (*authn.AuthnStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AuthnStatus_To_authn_AuthnStatus(a.(*AuthnStatus), b.(*authn.AuthnStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*authn.AuthnStatus)(nil), (*AuthnStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_authn_AuthnStatus_To_v1alpha1_AuthnStatus(a.(*authn.AuthnStatus), b.(*AuthnStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ComponentImage)(nil), (*authn.ComponentImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ComponentImage_To_authn_ComponentImage(a.(*ComponentImage), b.(*authn.ComponentImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*authn.ComponentImage)(nil), (*ComponentImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_authn_ComponentImage_To_v1alpha1_ComponentImage(a.(*authn.ComponentImage), b.(*ComponentImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RetryBackoff)(nil), (*authn.RetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RetryBackoff_To_authn_RetryBackoff(a.(*RetryBackoff), b.(*authn.RetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*authn.RetryBackoff)(nil), (*RetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_authn_RetryBackoff_To_v1alpha1_RetryBackoff(a.(*authn.RetryBackoff), b.(*RetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TenantAccess)(nil), (*authn.TenantAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TenantAccess_To_authn_TenantAccess(a.(*TenantAccess), b.(*authn.TenantAccess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*authn.TenantAccess)(nil), (*TenantAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_authn_TenantAccess_To_v1alpha1_TenantAccess(a.(*authn.TenantAccess), b.(*TenantAccess), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TokenWebhook)(nil), (*authn.TokenWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TokenWebhook_To_authn_TokenWebhook(a.(*TokenWebhook), b.(*authn.TokenWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*authn.TokenWebhook)(nil), (*TokenWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_authn_TokenWebhook_To_v1alpha1_TokenWebhook(a.(*authn.TokenWebhook), b.(*TokenWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WebhookStatus)(nil), (*authn.WebhookStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WebhookStatus_To_authn_WebhookStatus(a.(*WebhookStatus), b.(*authn.WebhookStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*authn.WebhookStatus)(nil), (*WebhookStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_authn_WebhookStatus_To_v1alpha1_WebhookStatus(a.(*authn.WebhookStatus), b.(*WebhookStatus), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
 func autoConvert_v1alpha1_AuthnConfig_To_authn_AuthnConfig(in *AuthnConfig, out *authn.AuthnConfig, s conversion.Scope) error {
 	out.Issuer = in.Issuer
 	out.ClientID = in.ClientID
+	out.Profile = in.Profile
+	out.AccessScope = authn.AccessScope(in.AccessScope)
+	out.AdditionalTenants = *(*[]authn.TenantAccess)(unsafe.Pointer(&in.AdditionalTenants))
+	out.TokenWebhook = (*authn.TokenWebhook)(unsafe.Pointer(in.TokenWebhook))
+	out.Suspended = in.Suspended
 	return nil
 }
 
@@ -49,6 +117,11 @@ func Convert_v1alpha1_AuthnConfig_To_authn_AuthnConfig(in *AuthnConfig, out *aut
 func autoConvert_authn_AuthnConfig_To_v1alpha1_AuthnConfig(in *authn.AuthnConfig, out *AuthnConfig, s conversion.Scope) error {
 	out.Issuer = in.Issuer
 	out.ClientID = in.ClientID
+	out.Profile = in.Profile
+	out.AccessScope = AccessScope(in.AccessScope)
+	out.AdditionalTenants = *(*[]TenantAccess)(unsafe.Pointer(&in.AdditionalTenants))
+	out.TokenWebhook = (*TokenWebhook)(unsafe.Pointer(in.TokenWebhook))
+	out.Suspended = in.Suspended
 	return nil
 }
 
@@ -56,3 +129,153 @@ func autoConvert_authn_AuthnConfig_To_v1alpha1_AuthnConfig(in *authn.AuthnConfig
 func Convert_authn_AuthnConfig_To_v1alpha1_AuthnConfig(in *authn.AuthnConfig, out *AuthnConfig, s conversion.Scope) error {
 	return autoConvert_authn_AuthnConfig_To_v1alpha1_AuthnConfig(in, out, s)
 }
+
+func autoConvert_v1alpha1_AuthnStatus_To_authn_AuthnStatus(in *AuthnStatus, out *authn.AuthnStatus, s conversion.Scope) error {
+	out.Issuers = *(*[]string)(unsafe.Pointer(&in.Issuers))
+	out.Tenant = in.Tenant
+	out.Profile = in.Profile
+	out.Images = *(*[]authn.ComponentImage)(unsafe.Pointer(&in.Images))
+	if err := Convert_v1alpha1_WebhookStatus_To_authn_WebhookStatus(&in.Webhook, &out.Webhook, s); err != nil {
+		return err
+	}
+	out.ConfigHash = in.ConfigHash
+	out.InputHash = in.InputHash
+	return nil
+}
+
+// Convert_v1alpha1_AuthnStatus_To_authn_AuthnStatus is an autogenerated conversion function.
+func Convert_v1alpha1_AuthnStatus_To_authn_AuthnStatus(in *AuthnStatus, out *authn.AuthnStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AuthnStatus_To_authn_AuthnStatus(in, out, s)
+}
+
+func autoConvert_authn_AuthnStatus_To_v1alpha1_AuthnStatus(in *authn.AuthnStatus, out *AuthnStatus, s conversion.Scope) error {
+	out.Issuers = *(*[]string)(unsafe.Pointer(&in.Issuers))
+	out.Tenant = in.Tenant
+	out.Profile = in.Profile
+	out.Images = *(*[]ComponentImage)(unsafe.Pointer(&in.Images))
+	if err := Convert_authn_WebhookStatus_To_v1alpha1_WebhookStatus(&in.Webhook, &out.Webhook, s); err != nil {
+		return err
+	}
+	out.ConfigHash = in.ConfigHash
+	out.InputHash = in.InputHash
+	return nil
+}
+
+// Convert_authn_AuthnStatus_To_v1alpha1_AuthnStatus is an autogenerated conversion function.
+func Convert_authn_AuthnStatus_To_v1alpha1_AuthnStatus(in *authn.AuthnStatus, out *AuthnStatus, s conversion.Scope) error {
+	return autoConvert_authn_AuthnStatus_To_v1alpha1_AuthnStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_ComponentImage_To_authn_ComponentImage(in *ComponentImage, out *authn.ComponentImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1alpha1_ComponentImage_To_authn_ComponentImage is an autogenerated conversion function.
+func Convert_v1alpha1_ComponentImage_To_authn_ComponentImage(in *ComponentImage, out *authn.ComponentImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ComponentImage_To_authn_ComponentImage(in, out, s)
+}
+
+func autoConvert_authn_ComponentImage_To_v1alpha1_ComponentImage(in *authn.ComponentImage, out *ComponentImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_authn_ComponentImage_To_v1alpha1_ComponentImage is an autogenerated conversion function.
+func Convert_authn_ComponentImage_To_v1alpha1_ComponentImage(in *authn.ComponentImage, out *ComponentImage, s conversion.Scope) error {
+	return autoConvert_authn_ComponentImage_To_v1alpha1_ComponentImage(in, out, s)
+}
+
+func autoConvert_v1alpha1_RetryBackoff_To_authn_RetryBackoff(in *RetryBackoff, out *authn.RetryBackoff, s conversion.Scope) error {
+	out.InitialDelay = (*v1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	return nil
+}
+
+// Convert_v1alpha1_RetryBackoff_To_authn_RetryBackoff is an autogenerated conversion function.
+func Convert_v1alpha1_RetryBackoff_To_authn_RetryBackoff(in *RetryBackoff, out *authn.RetryBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RetryBackoff_To_authn_RetryBackoff(in, out, s)
+}
+
+func autoConvert_authn_RetryBackoff_To_v1alpha1_RetryBackoff(in *authn.RetryBackoff, out *RetryBackoff, s conversion.Scope) error {
+	out.InitialDelay = (*v1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	return nil
+}
+
+// Convert_authn_RetryBackoff_To_v1alpha1_RetryBackoff is an autogenerated conversion function.
+func Convert_authn_RetryBackoff_To_v1alpha1_RetryBackoff(in *authn.RetryBackoff, out *RetryBackoff, s conversion.Scope) error {
+	return autoConvert_authn_RetryBackoff_To_v1alpha1_RetryBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha1_TenantAccess_To_authn_TenantAccess(in *TenantAccess, out *authn.TenantAccess, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RoleMapping = *(*map[string]string)(unsafe.Pointer(&in.RoleMapping))
+	return nil
+}
+
+// Convert_v1alpha1_TenantAccess_To_authn_TenantAccess is an autogenerated conversion function.
+func Convert_v1alpha1_TenantAccess_To_authn_TenantAccess(in *TenantAccess, out *authn.TenantAccess, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TenantAccess_To_authn_TenantAccess(in, out, s)
+}
+
+func autoConvert_authn_TenantAccess_To_v1alpha1_TenantAccess(in *authn.TenantAccess, out *TenantAccess, s conversion.Scope) error {
+	out.Name = in.Name
+	out.RoleMapping = *(*map[string]string)(unsafe.Pointer(&in.RoleMapping))
+	return nil
+}
+
+// Convert_authn_TenantAccess_To_v1alpha1_TenantAccess is an autogenerated conversion function.
+func Convert_authn_TenantAccess_To_v1alpha1_TenantAccess(in *authn.TenantAccess, out *TenantAccess, s conversion.Scope) error {
+	return autoConvert_authn_TenantAccess_To_v1alpha1_TenantAccess(in, out, s)
+}
+
+func autoConvert_v1alpha1_TokenWebhook_To_authn_TokenWebhook(in *TokenWebhook, out *authn.TokenWebhook, s conversion.Scope) error {
+	out.CacheTTL = (*v1.Duration)(unsafe.Pointer(in.CacheTTL))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RetryBackoff = (*authn.RetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	return nil
+}
+
+// Convert_v1alpha1_TokenWebhook_To_authn_TokenWebhook is an autogenerated conversion function.
+func Convert_v1alpha1_TokenWebhook_To_authn_TokenWebhook(in *TokenWebhook, out *authn.TokenWebhook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TokenWebhook_To_authn_TokenWebhook(in, out, s)
+}
+
+func autoConvert_authn_TokenWebhook_To_v1alpha1_TokenWebhook(in *authn.TokenWebhook, out *TokenWebhook, s conversion.Scope) error {
+	out.CacheTTL = (*v1.Duration)(unsafe.Pointer(in.CacheTTL))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RetryBackoff = (*RetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	return nil
+}
+
+// Convert_authn_TokenWebhook_To_v1alpha1_TokenWebhook is an autogenerated conversion function.
+func Convert_authn_TokenWebhook_To_v1alpha1_TokenWebhook(in *authn.TokenWebhook, out *TokenWebhook, s conversion.Scope) error {
+	return autoConvert_authn_TokenWebhook_To_v1alpha1_TokenWebhook(in, out, s)
+}
+
+func autoConvert_v1alpha1_WebhookStatus_To_authn_WebhookStatus(in *WebhookStatus, out *authn.WebhookStatus, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Mode = authn.AuthenticationMode(in.Mode)
+	out.Suspended = in.Suspended
+	return nil
+}
+
+// Convert_v1alpha1_WebhookStatus_To_authn_WebhookStatus is an autogenerated conversion function.
+func Convert_v1alpha1_WebhookStatus_To_authn_WebhookStatus(in *WebhookStatus, out *authn.WebhookStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WebhookStatus_To_authn_WebhookStatus(in, out, s)
+}
+
+func autoConvert_authn_WebhookStatus_To_v1alpha1_WebhookStatus(in *authn.WebhookStatus, out *WebhookStatus, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Mode = AuthenticationMode(in.Mode)
+	out.Suspended = in.Suspended
+	return nil
+}
+
+// Convert_authn_WebhookStatus_To_v1alpha1_WebhookStatus is an autogenerated conversion function.
+func Convert_authn_WebhookStatus_To_v1alpha1_WebhookStatus(in *authn.WebhookStatus, out *WebhookStatus, s conversion.Scope) error {
+	return autoConvert_authn_WebhookStatus_To_v1alpha1_WebhookStatus(in, out, s)
+}
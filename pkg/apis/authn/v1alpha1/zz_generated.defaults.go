@@ -17,5 +17,10 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&AuthnConfig{}, func(obj interface{}) { SetObjectDefaults_AuthnConfig(obj.(*AuthnConfig)) })
 	return nil
 }
+
+func SetObjectDefaults_AuthnConfig(in *AuthnConfig) {
+	SetDefaults_AuthnConfig(in)
+}
@@ -0,0 +1,193 @@
+package validation
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var supportedAccessScopes = []string{
+	string(authn.AccessScopeTenant),
+	string(authn.AccessScopeProject),
+}
+
+// ValidateAuthnConfig validates the given authn configuration against the tenant and the annotations of the shoot it belongs to.
+func ValidateAuthnConfig(cfg *authn.AuthnConfig, tenant string, shootAnnotations map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.Issuer == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("issuer"), "issuer must be set in the provider config or the auth profile"))
+	}
+	if cfg.ClientID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("clientID"), "client id must be set in the provider config or the auth profile"))
+	}
+
+	switch cfg.AccessScope {
+	case "", authn.AccessScopeTenant:
+	case authn.AccessScopeProject:
+		if shootAnnotations[tag.ClusterProject] == "" {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("accessScope"), cfg.AccessScope, "shoot has no "+tag.ClusterProject+" annotation"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("accessScope"), cfg.AccessScope, supportedAccessScopes))
+	}
+
+	allErrs = append(allErrs, validateTokenWebhook(cfg.TokenWebhook, field.NewPath("tokenWebhook"))...)
+	allErrs = append(allErrs, validateAdditionalTenants(cfg.AdditionalTenants, tenant, field.NewPath("additionalTenants"))...)
+
+	return allErrs
+}
+
+// ValidateAuthenticatorSupport validates that the given authn configuration only uses features that are implemented
+// by the given authenticator image. The external authenticator ignores the settings it does not know, which would
+// silently grant access to users that should be rejected.
+func ValidateAuthenticatorSupport(cfg *authn.AuthnConfig, authenticatorImage string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if authenticatorImage == imagevector.ImageNameFitsAuthnWebhook {
+		return allErrs
+	}
+
+	detail := "requires the authenticator image " + imagevector.ImageNameFitsAuthnWebhook + ", the auth profile uses " + authenticatorImage
+
+	if cfg.AccessScope == authn.AccessScopeProject {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("accessScope"), cfg.AccessScope, detail))
+	}
+	if len(cfg.AdditionalTenants) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("additionalTenants"), detail))
+	}
+	if tw := cfg.TokenWebhook; tw != nil {
+		if tw.Timeout != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("tokenWebhook", "timeout"), detail))
+		}
+		if tw.RetryBackoff != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("tokenWebhook", "retryBackoff"), detail))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAdditionalTenants(tenants []authn.TenantAccess, owningTenant string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.New[string]()
+	for i, tenant := range tenants {
+		idxPath := fldPath.Index(i)
+		name := strings.ToLower(tenant.Name)
+
+		switch {
+		case name == "":
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "tenant name must be set"))
+		case name == strings.ToLower(owningTenant):
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), tenant.Name, "must not be the tenant owning the cluster"))
+		case names.Has(name):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), tenant.Name))
+		default:
+			for _, msg := range validation.IsValidLabelValue(name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), tenant.Name, msg))
+			}
+		}
+		names.Insert(name)
+
+		if len(tenant.RoleMapping) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("roleMapping"), "at least one role must be mapped"))
+		}
+		for _, role := range slices.Sorted(maps.Keys(tenant.RoleMapping)) {
+			clusterRole := tenant.RoleMapping[role]
+			if role == "" || strings.ContainsAny(role, ":,=") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("roleMapping"), role, "role must not be empty or contain any of ':,='"))
+			}
+			if clusterRole == "" || strings.ContainsAny(clusterRole, ",=") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("roleMapping").Key(role), clusterRole, "cluster role must not be empty or contain any of ',='"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// NativeAuthentication is the authentication that gardener configures for the kube-apiserver of a shoot.
+type NativeAuthentication struct {
+	// OIDC is the oidc configuration of the shoot.
+	OIDC *gardencorev1beta1.OIDCConfig
+	// StructuredIssuers are the issuer urls of the jwt authenticators of the structured authentication configuration.
+	StructuredIssuers []string
+	// TokenWebhookConfigFile is the config file of a token webhook of someone else that the kube-apiserver uses.
+	TokenWebhookConfigFile string
+}
+
+// ValidateNativeAuthentication validates that the native authentication of a shoot can be combined with the token
+// webhook. Authenticators for the same issuer would accept the tokens without the tenant checks of the webhook,
+// and oidc groups without prefix could claim the groups that are granted by the webhook.
+func ValidateNativeAuthentication(issuer string, native NativeAuthentication) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if oidc := native.OIDC; oidc != nil {
+		fldPath := field.NewPath("spec", "kubernetes", "kubeAPIServer", "oidcConfig")
+
+		if oidc.IssuerURL != nil && sameIssuer(*oidc.IssuerURL, issuer) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("issuerURL"), "must not be the issuer of the fits-authn extension, remove the oidc config or use a different issuer"))
+		}
+		if prefix := ptr.Deref(oidc.GroupsPrefix, ""); prefix == "" || prefix == "-" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("groupsPrefix"), "must be set when combined with the fits-authn extension, otherwise oidc groups could claim the groups of the extension"))
+		}
+	}
+
+	for i, structured := range native.StructuredIssuers {
+		if sameIssuer(structured, issuer) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("jwt").Index(i).Child("issuer", "url"), "structured authentication must not contain the issuer of the fits-authn extension"))
+		}
+	}
+
+	if native.TokenWebhookConfigFile != "" {
+		// the kube-apiserver only supports a single token webhook, so it must not be replaced
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("authenticationTokenWebhookConfigFile"), "kube-apiserver already uses the token webhook config file "+native.TokenWebhookConfigFile+", it cannot be combined with the fits-authn extension"))
+	}
+
+	return allErrs
+}
+
+func sameIssuer(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// maxTokenWebhookTimeout is the timeout after which the kube-apiserver gives up on the token webhook.
+const maxTokenWebhookTimeout = 30 * time.Second
+
+func validateTokenWebhook(tw *authn.TokenWebhook, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tw == nil {
+		return allErrs
+	}
+
+	if tw.CacheTTL != nil && tw.CacheTTL.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cacheTTL"), tw.CacheTTL.Duration.String(), "must not be negative"))
+	}
+	if tw.Timeout != nil && (tw.Timeout.Duration <= 0 || tw.Timeout.Duration > maxTokenWebhookTimeout) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), tw.Timeout.Duration.String(), "must be positive and at most "+maxTokenWebhookTimeout.String()))
+	}
+
+	if rb := tw.RetryBackoff; rb != nil {
+		if rb.InitialDelay != nil && rb.InitialDelay.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("retryBackoff", "initialDelay"), rb.InitialDelay.Duration.String(), "must be positive"))
+		}
+		if rb.Retries != nil && (*rb.Retries < 0 || *rb.Retries > 10) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("retryBackoff", "retries"), *rb.Retries, "must be between 0 and 10"))
+		}
+	}
+
+	return allErrs
+}
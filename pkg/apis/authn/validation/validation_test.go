@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateAuthenticatorSupport(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *authn.AuthnConfig
+		image      string
+		wantFields []string
+	}{
+		{
+			name:  "plain config with the authn-webhook",
+			cfg:   &authn.AuthnConfig{AccessScope: authn.AccessScopeTenant},
+			image: imagevector.ImageNameAuthnWebhook,
+		},
+		{
+			name: "all features with the fits-authn-webhook",
+			cfg: &authn.AuthnConfig{
+				AccessScope:       authn.AccessScopeProject,
+				AdditionalTenants: []authn.TenantAccess{{Name: "partner"}},
+				TokenWebhook: &authn.TokenWebhook{
+					Timeout:      &metav1.Duration{Duration: time.Second},
+					RetryBackoff: &authn.RetryBackoff{},
+				},
+			},
+			image: imagevector.ImageNameFitsAuthnWebhook,
+		},
+		{
+			name:       "project scope with the authn-webhook",
+			cfg:        &authn.AuthnConfig{AccessScope: authn.AccessScopeProject},
+			image:      imagevector.ImageNameAuthnWebhook,
+			wantFields: []string{"accessScope"},
+		},
+		{
+			name:       "additional tenants with the authn-webhook",
+			cfg:        &authn.AuthnConfig{AdditionalTenants: []authn.TenantAccess{{Name: "partner"}}},
+			image:      imagevector.ImageNameAuthnWebhook,
+			wantFields: []string{"additionalTenants"},
+		},
+		{
+			name: "timeout and retries with the authn-webhook",
+			cfg: &authn.AuthnConfig{
+				TokenWebhook: &authn.TokenWebhook{
+					CacheTTL:     &metav1.Duration{Duration: time.Minute},
+					Timeout:      &metav1.Duration{Duration: time.Second},
+					RetryBackoff: &authn.RetryBackoff{},
+				},
+			},
+			image:      imagevector.ImageNameAuthnWebhook,
+			wantFields: []string{"tokenWebhook.timeout", "tokenWebhook.retryBackoff"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateAuthenticatorSupport(tt.cfg, tt.image)
+
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if len(got) != len(tt.wantFields) {
+				t.Fatalf("ValidateAuthenticatorSupport() = %v, want errors for %v", errs, tt.wantFields)
+			}
+			for i := range got {
+				if got[i] != tt.wantFields[i] {
+					t.Errorf("error %d is for field %q, want %q", i, got[i], tt.wantFields[i])
+				}
+			}
+		})
+	}
+}
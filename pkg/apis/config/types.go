@@ -13,13 +13,93 @@ type ControllerConfiguration struct {
 	metav1.TypeMeta
 
 	// Auth is the configuration for fi-ts specific user authentication in the cluster.
+	// It serves as the default profile.
 	Auth Auth
 
+	// Profiles are named auth configurations that shoots can select instead of the default one.
+	Profiles []AuthProfile
+
 	// HealthCheckConfig is the config for the health check controller
 	HealthCheckConfig *healthcheckconfig.HealthCheckConfig
 
 	// ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
 	ImagePullSecret *ImagePullSecret
+
+	// GroupRoleBindingController is the configuration for the controller that binds namespace groups to cluster roles.
+	GroupRoleBindingController *GroupRoleBindingController
+
+	// ProjectMembers configures the synchronization of the garden project members into the shoot RBAC.
+	ProjectMembers *ProjectMembers
+
+	// TokenWebhook contains the default settings of the token webhook.
+	TokenWebhook *TokenWebhook
+
+	// Authorization configures the optional authorization webhook.
+	Authorization *Authorization
+
+	// BreakGlass configures the emergency credentials of the shoots.
+	BreakGlass *BreakGlass
+
+	// OIDCKubeconfig configures the kubeconfig that is published to the project namespaces of the shoots.
+	OIDCKubeconfig *OIDCKubeconfig
+
+	// ManagedResources configures how the actuator waits for the managed resources of the extension.
+	ManagedResources *ManagedResources
+}
+
+// ManagedResources configures how the actuator waits for the managed resources of the extension.
+type ManagedResources struct {
+	// HealthTimeout is the duration after which unhealthy managed resources are reported as failed.
+	HealthTimeout *metav1.Duration
+	// DeletionTimeout is the duration after which the deletion of the managed resources is reported as failed.
+	DeletionTimeout *metav1.Duration
+}
+
+// OIDCKubeconfig configures the kubeconfig that is published to the project namespaces of the shoots.
+type OIDCKubeconfig struct {
+	// ExtraScopes are the scopes that are requested in addition to openid.
+	ExtraScopes []string
+}
+
+// BreakGlass configures the emergency credentials of the shoots.
+type BreakGlass struct {
+	// GardenNamespace is the namespace in the garden cluster the credentials are published to.
+	GardenNamespace string
+}
+
+// Authorization configures the optional authorization webhook.
+type Authorization struct {
+	// Enabled adds the authorization webhook to the authorizer chain of the kube-apiserver.
+	Enabled bool
+	// ProviderTenantDeny are the requests that are denied for members of the provider tenant.
+	ProviderTenantDeny []ResourceRule
+	// FailurePolicy defines how the kube-apiserver decides about the requests of users authenticated by the
+	// token webhook if the authorization webhook is not reachable.
+	FailurePolicy AuthorizationFailurePolicy
+}
+
+// AuthorizationFailurePolicy defines how the kube-apiserver decides if the authorization webhook is not reachable.
+type AuthorizationFailurePolicy string
+
+const (
+	// AuthorizationFailurePolicyDeny denies the requests.
+	AuthorizationFailurePolicyDeny AuthorizationFailurePolicy = "Deny"
+	// AuthorizationFailurePolicyNoOpinion leaves the decision to RBAC, which allows the requests that should be denied.
+	AuthorizationFailurePolicyNoOpinion AuthorizationFailurePolicy = "NoOpinion"
+)
+
+// ResourceRule matches resource requests.
+type ResourceRule struct {
+	// Verbs are the verbs of the requests.
+	Verbs []string
+	// Resources are the resources of the requests including the subresource.
+	Resources []string
+}
+
+// ProjectMembers configures the synchronization of the garden project members into the shoot RBAC.
+type ProjectMembers struct {
+	// RoleMapping maps the roles of the project members to the cluster roles that are bound in the shoot.
+	RoleMapping map[string]string
 }
 
 // Auth contains the configuration for fi-ts specific user authentication in the cluster.
@@ -27,9 +107,106 @@ type Auth struct {
 	// ProviderTenant is the name of the provider tenant who has special privileges.
 	ProviderTenant string
 
-	MetalURL      string
-	MetalHMAC     string
+	// AuthenticatorImage is the name of the image vector entry that is deployed as the token authenticator.
+	AuthenticatorImage string
+
+	// Issuer is the issuer of shoots that do not specify one.
+	Issuer string
+	// ClientID is the client id of shoots that do not specify one.
+	ClientID string
+	// GroupsPrefix is the application prefix of the token groups that refer to the clusters.
+	GroupsPrefix string
+
+	// MetalURL is the url of the metal-api.
+	// Deprecated: use Membership.MetalAPI instead.
+	MetalURL string
+	// MetalHMAC is the hmac key for the metal-api.
+	// Deprecated: use Membership.MetalAPI instead.
+	MetalHMAC string
+	// MetalAuthType is the hmac auth type for the metal-api.
+	// Deprecated: use Membership.MetalAPI instead.
 	MetalAuthType string
+
+	// Membership selects and configures the backend that resolves the memberships of users.
+	Membership *MembershipBackend
+
+	// ProjectTenant configures how the tenant is derived from the garden project of a shoot without tenant annotation.
+	ProjectTenant *ProjectTenant
+}
+
+// ProjectTenant configures how the tenant is derived from the garden project of a shoot.
+type ProjectTenant struct {
+	// Label is the label of the project that holds the tenant.
+	Label string
+	// Annotation is the annotation of the project that holds the tenant.
+	Annotation string
+	// CacheTTL is the duration for which the tenant of a project is cached.
+	CacheTTL *metav1.Duration
+}
+
+// DefaultProfileName is the name of the profile formed by the top-level auth configuration.
+const DefaultProfileName = "default"
+
+// AuthProfile is a named auth configuration.
+type AuthProfile struct {
+	// Name is the name of the profile.
+	Name string
+	// Tenants are the tenants whose shoots use this profile unless they select a profile explicitly.
+	Tenants []string
+	// Auth is the auth configuration of the profile.
+	Auth
+}
+
+// MembershipBackendType is the type of a membership backend.
+type MembershipBackendType string
+
+const (
+	// MembershipBackendMetalAPI resolves memberships through the metal-api with hmac authentication.
+	MembershipBackendMetalAPI MembershipBackendType = "MetalAPI"
+	// MembershipBackendMetalV2 resolves memberships through the metal-stack v2 api with an api token.
+	MembershipBackendMetalV2 MembershipBackendType = "MetalV2"
+	// MembershipBackendStatic resolves memberships from a static mapping.
+	MembershipBackendStatic MembershipBackendType = "Static"
+	// MembershipBackendNone only uses the groups contained in the token.
+	MembershipBackendNone MembershipBackendType = "None"
+)
+
+// MembershipBackend selects and configures the backend that resolves the memberships of users.
+type MembershipBackend struct {
+	// Type is the type of the backend.
+	Type MembershipBackendType
+	// MetalAPI contains the configuration for the MetalAPI backend.
+	MetalAPI *MetalAPIMembership
+	// MetalV2 contains the configuration for the MetalV2 backend.
+	MetalV2 *MetalV2Membership
+	// Static contains the configuration for the Static backend.
+	Static *StaticMembership
+}
+
+// MetalAPIMembership contains the configuration for the MetalAPI membership backend.
+type MetalAPIMembership struct {
+	// URL is the url of the metal-api.
+	URL string
+	// HMAC is the hmac key for the metal-api.
+	HMAC string
+	// AuthType is the hmac auth type, e.g. "Metal-View".
+	AuthType string
+}
+
+// MetalV2Membership contains the configuration for the MetalV2 membership backend.
+type MetalV2Membership struct {
+	// URL is the url of the metal-stack v2 api.
+	URL string
+	// Token is the api token.
+	Token string
+}
+
+// StaticMembership contains the configuration for the Static membership backend.
+type StaticMembership struct {
+	// Users maps user names to kubernetes groups.
+	Users map[string][]string
+	// Groups maps token groups to kubernetes groups.
+	Groups map[string][]string
 }
 
 // ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
@@ -37,3 +214,45 @@ type ImagePullSecret struct {
 	// DockerConfigJSON contains the already base64 encoded JSON content for the image pull secret
 	DockerConfigJSON string
 }
+
+// GroupRoleBindingControllerMode defines where the group rolebinding controller runs.
+type GroupRoleBindingControllerMode string
+
+const (
+	// GroupRoleBindingControllerModeDeployment runs the controller as a deployment in the shoot namespace of the seed.
+	GroupRoleBindingControllerModeDeployment GroupRoleBindingControllerMode = "Deployment"
+	// GroupRoleBindingControllerModeExtension runs the controller inside the extension process.
+	GroupRoleBindingControllerModeExtension GroupRoleBindingControllerMode = "Extension"
+)
+
+// GroupRoleBindingController contains the configuration for the controller that binds namespace groups to cluster roles.
+type GroupRoleBindingController struct {
+	// Mode defines where the controller runs.
+	Mode GroupRoleBindingControllerMode
+	// ExcludeNamespaces are the namespaces in which no rolebindings are created.
+	ExcludeNamespaces []string
+	// ExpectedGroups are the cluster roles that are bound to a group in every namespace.
+	ExpectedGroups []string
+	// SyncPeriod is the interval in which the rolebindings of a shoot are synced when running in the extension process.
+	SyncPeriod *metav1.Duration
+}
+
+// TokenWebhook contains the settings of the token webhook.
+type TokenWebhook struct {
+	// CacheTTL is the duration for which the kube-apiserver caches the responses of the token webhook.
+	CacheTTL *metav1.Duration
+	// Timeout is the timeout for authenticating a token including the requests to the issuer and the membership backend.
+	Timeout *metav1.Duration
+	// RetryBackoff defines how failed requests to the membership backend are retried.
+	RetryBackoff *RetryBackoff
+	// ReadinessTimeout is the maximum duration the kube-apiserver waits for the token webhook before it starts.
+	ReadinessTimeout *metav1.Duration
+}
+
+// RetryBackoff defines how failed requests are retried.
+type RetryBackoff struct {
+	// InitialDelay is the delay before the first retry, it is doubled for every further retry.
+	InitialDelay *metav1.Duration
+	// Retries is the maximum number of retries.
+	Retries *int32
+}
@@ -1,9 +1,137 @@
 package v1alpha1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_ControllerConfiguration sets the defaults for the controller configuration.
+func SetDefaults_ControllerConfiguration(cfg *ControllerConfiguration) {
+	if cfg.GroupRoleBindingController == nil {
+		cfg.GroupRoleBindingController = &GroupRoleBindingController{}
+	}
+	if cfg.TokenWebhook == nil {
+		cfg.TokenWebhook = &TokenWebhook{}
+	}
+	if cfg.ManagedResources == nil {
+		cfg.ManagedResources = &ManagedResources{}
+	}
+}
+
+// SetDefaults_ManagedResources sets the defaults for waiting on the managed resources.
+func SetDefaults_ManagedResources(cfg *ManagedResources) {
+	if cfg.HealthTimeout == nil {
+		cfg.HealthTimeout = &metav1.Duration{Duration: 2 * time.Minute}
+	}
+	if cfg.DeletionTimeout == nil {
+		cfg.DeletionTimeout = &metav1.Duration{Duration: 2 * time.Minute}
+	}
+}
+
+// SetDefaults_Auth sets the defaults for the auth configuration.
+func SetDefaults_Auth(cfg *Auth) {
+	if cfg.AuthenticatorImage == "" {
+		cfg.AuthenticatorImage = "authn-webhook"
+	}
+	if cfg.GroupsPrefix == "" {
+		cfg.GroupsPrefix = "k8s"
+	}
+	if cfg.Membership == nil && cfg.MetalURL == "" {
+		cfg.Membership = &MembershipBackend{
+			Type: MembershipBackendNone,
+		}
+	}
+	if cfg.Membership == nil {
+		// keep configurations working that only contain the deprecated metal fields
+		cfg.Membership = &MembershipBackend{
+			Type: MembershipBackendMetalAPI,
+			MetalAPI: &MetalAPIMembership{
+				URL:      cfg.MetalURL,
+				HMAC:     cfg.MetalHMAC,
+				AuthType: cfg.MetalAuthType,
+			},
+		}
+	}
+}
+
+// SetDefaults_ProjectTenant sets the defaults for deriving the tenant from the garden project.
+func SetDefaults_ProjectTenant(cfg *ProjectTenant) {
+	if cfg.CacheTTL == nil {
+		cfg.CacheTTL = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+}
+
+// SetDefaults_ProjectMembers sets the defaults for the synchronization of the project members.
+func SetDefaults_ProjectMembers(cfg *ProjectMembers) {
+	if cfg.RoleMapping == nil {
+		cfg.RoleMapping = map[string]string{
+			"admin":  "cluster-admin",
+			"viewer": "view",
+		}
+	}
+}
+
+// SetDefaults_TokenWebhook sets the defaults for the token webhook.
+func SetDefaults_TokenWebhook(cfg *TokenWebhook) {
+	if cfg.CacheTTL == nil {
+		cfg.CacheTTL = &metav1.Duration{Duration: 2 * time.Minute}
+	}
+	if cfg.RetryBackoff == nil {
+		cfg.RetryBackoff = &RetryBackoff{}
+	}
+}
+
+// SetDefaults_RetryBackoff sets the defaults for retrying failed requests.
+func SetDefaults_RetryBackoff(cfg *RetryBackoff) {
+	if cfg.InitialDelay == nil {
+		cfg.InitialDelay = &metav1.Duration{Duration: 500 * time.Millisecond}
+	}
+	if cfg.Retries == nil {
+		cfg.Retries = ptr.To(int32(0))
+	}
+}
+
+// SetDefaults_Authorization sets the defaults for the authorization webhook.
+func SetDefaults_Authorization(cfg *Authorization) {
+	if cfg.ProviderTenantDeny == nil {
+		cfg.ProviderTenantDeny = []ResourceRule{
+			{
+				Verbs:     []string{"*"},
+				Resources: []string{"pods/exec", "pods/attach", "pods/portforward"},
+			},
+		}
+	}
+	if cfg.FailurePolicy == "" {
+		cfg.FailurePolicy = AuthorizationFailurePolicyDeny
+	}
+}
+
+// SetDefaults_OIDCKubeconfig sets the defaults for the published kubeconfig.
+func SetDefaults_OIDCKubeconfig(cfg *OIDCKubeconfig) {
+	if cfg.ExtraScopes == nil {
+		cfg.ExtraScopes = []string{"email", "groups", "profile"}
+	}
+}
+
+// SetDefaults_GroupRoleBindingController sets the defaults for the group rolebinding controller configuration.
+func SetDefaults_GroupRoleBindingController(cfg *GroupRoleBindingController) {
+	if cfg.Mode == "" {
+		cfg.Mode = GroupRoleBindingControllerModeDeployment
+	}
+	if cfg.ExcludeNamespaces == nil {
+		cfg.ExcludeNamespaces = []string{"kube-system", "kube-public", "kube-node-lease", "default"}
+	}
+	if cfg.ExpectedGroups == nil {
+		cfg.ExpectedGroups = []string{"admin", "edit", "view"}
+	}
+	if cfg.SyncPeriod == nil {
+		cfg.SyncPeriod = &metav1.Duration{Duration: 5 * time.Minute}
+	}
+}
@@ -13,14 +13,118 @@ type ControllerConfiguration struct {
 	metav1.TypeMeta `json:",inline"`
 
 	// Auth is the configuration for fi-ts specific user authentication in the cluster.
+	// It serves as the default profile for shoots that neither select a profile nor match the tenants of one.
 	Auth Auth `json:"auth"`
 
+	// Profiles are named auth configurations that shoots can select instead of the default one.
+	// +optional
+	Profiles []AuthProfile `json:"profiles,omitempty"`
+
 	// HealthCheckConfig is the config for the health check controller
 	// +optional
 	HealthCheckConfig *healthcheckconfigv1alpha1.HealthCheckConfig `json:"healthCheckConfig,omitempty"`
 
 	// ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
 	ImagePullSecret *ImagePullSecret `json:"imagePullSecret,omitempty"`
+
+	// GroupRoleBindingController is the configuration for the controller that binds namespace groups to cluster roles.
+	// +optional
+	GroupRoleBindingController *GroupRoleBindingController `json:"groupRoleBindingController,omitempty"`
+
+	// ProjectMembers configures the synchronization of the garden project members into the shoot RBAC.
+	// If not set, the project members are not synchronized.
+	// +optional
+	ProjectMembers *ProjectMembers `json:"projectMembers,omitempty"`
+
+	// TokenWebhook contains the default settings of the token webhook, shoots can override them in their provider config.
+	// +optional
+	TokenWebhook *TokenWebhook `json:"tokenWebhook,omitempty"`
+
+	// Authorization configures the optional authorization webhook.
+	// +optional
+	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// BreakGlass configures the emergency credentials of the shoots. If not set, no emergency credentials are issued.
+	// +optional
+	BreakGlass *BreakGlass `json:"breakGlass,omitempty"`
+
+	// OIDCKubeconfig configures the kubeconfig that is published to the project namespaces of the shoots.
+	// If not set, no kubeconfig is published.
+	// +optional
+	OIDCKubeconfig *OIDCKubeconfig `json:"oidcKubeconfig,omitempty"`
+
+	// ManagedResources configures how the actuator waits for the managed resources of the extension.
+	// +optional
+	ManagedResources *ManagedResources `json:"managedResources,omitempty"`
+}
+
+// ManagedResources configures how the actuator waits for the managed resources of the extension.
+type ManagedResources struct {
+	// HealthTimeout is the duration after which managed resources that are not applied and healthy are reported as
+	// failed, defaults to 2m. The actuator does not block while waiting, the health is checked again periodically.
+	// +optional
+	HealthTimeout *metav1.Duration `json:"healthTimeout,omitempty"`
+	// DeletionTimeout is the duration after which the deletion of the managed resources is reported as failed,
+	// defaults to 2m. The actuator does not block while waiting, the deletion is checked again periodically.
+	// +optional
+	DeletionTimeout *metav1.Duration `json:"deletionTimeout,omitempty"`
+}
+
+// OIDCKubeconfig configures the kubeconfig that is published to the project namespaces of the shoots.
+// The kubeconfig uses the kubectl oidc-login plugin with the issuer and client id of the shoot and is stored
+// in the config map "<shoot>.oidc-kubeconfig". The extension needs to be allowed to manage config maps in the
+// project namespaces.
+type OIDCKubeconfig struct {
+	// ExtraScopes are the scopes that are requested in addition to openid, defaults to email, groups and profile.
+	// +optional
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+}
+
+// BreakGlass configures the emergency credentials of the shoots. They are accepted by the token webhook without
+// asking the issuer or the membership backend, such that the provider can still access a shoot if those are down.
+// The credentials are bound to a dedicated cluster role in the shoot and every use is recorded as an event.
+// They require the fits-authn-webhook image.
+type BreakGlass struct {
+	// GardenNamespace is the namespace in the garden cluster the credentials are published to. Access to it
+	// needs to be restricted to the provider tenant, the extension needs to be allowed to manage secrets in it.
+	// It must not be a project namespace, which are garden and the ones prefixed with garden-.
+	GardenNamespace string `json:"gardenNamespace"`
+}
+
+// Authorization configures the optional authorization webhook. It is queried by the kube-apiserver before RBAC
+// and only ever denies requests, permissions are still granted by RBAC. It requires the fits-authn-webhook image.
+type Authorization struct {
+	// Enabled adds the authorization webhook to the authorizer chain of the kube-apiserver.
+	Enabled bool `json:"enabled"`
+	// ProviderTenantDeny are the requests that are denied for members of the provider tenant,
+	// defaults to exec, attach and port forwarding into pods.
+	// +optional
+	ProviderTenantDeny []ResourceRule `json:"providerTenantDeny,omitempty"`
+	// FailurePolicy defines how the kube-apiserver decides about the requests of users authenticated by the
+	// token webhook if the authorization webhook is not reachable, defaults to Deny. NoOpinion leaves the decision
+	// to RBAC, which allows the denied requests during an outage of the webhook. Deny requires the kube-apiserver
+	// to use structured authorization.
+	// +optional
+	FailurePolicy AuthorizationFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// AuthorizationFailurePolicy defines how the kube-apiserver decides if the authorization webhook is not reachable.
+type AuthorizationFailurePolicy string
+
+const (
+	// AuthorizationFailurePolicyDeny denies the requests.
+	AuthorizationFailurePolicyDeny AuthorizationFailurePolicy = "Deny"
+	// AuthorizationFailurePolicyNoOpinion leaves the decision to RBAC, which allows the requests that should be denied.
+	AuthorizationFailurePolicyNoOpinion AuthorizationFailurePolicy = "NoOpinion"
+)
+
+// ResourceRule matches resource requests.
+type ResourceRule struct {
+	// Verbs are the verbs of the requests, "*" matches all verbs.
+	Verbs []string `json:"verbs"`
+	// Resources are the resources of the requests including the subresource, e.g. "pods/exec".
+	// "*" matches all resources.
+	Resources []string `json:"resources"`
 }
 
 // Auth contains the configuration for fi-ts specific user authentication in the cluster.
@@ -28,9 +132,126 @@ type Auth struct {
 	// ProviderTenant is the name of the provider tenant who has special privileges.
 	ProviderTenant string `json:"providerTenant"`
 
-	MetalURL      string `json:"metalURL"`
-	MetalHMAC     string `json:"metalHMAC"`
-	MetalAuthType string `json:"metalAuthType"`
+	// AuthenticatorImage is the name of the image vector entry that is deployed as the token authenticator,
+	// either "authn-webhook" or "fits-authn-webhook". Defaults to "authn-webhook".
+	// +optional
+	AuthenticatorImage string `json:"authenticatorImage,omitempty"`
+
+	// Issuer is the issuer of shoots that do not specify one.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// ClientID is the client id of shoots that do not specify one.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+	// GroupsPrefix is the application prefix of the token groups that refer to the clusters, defaults to "k8s".
+	// +optional
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+
+	// MetalURL is the url of the metal-api.
+	// Deprecated: use Membership.MetalAPI instead.
+	// +optional
+	MetalURL string `json:"metalURL,omitempty"`
+	// MetalHMAC is the hmac key for the metal-api.
+	// Deprecated: use Membership.MetalAPI instead.
+	// +optional
+	MetalHMAC string `json:"metalHMAC,omitempty"`
+	// MetalAuthType is the hmac auth type for the metal-api.
+	// Deprecated: use Membership.MetalAPI instead.
+	// +optional
+	MetalAuthType string `json:"metalAuthType,omitempty"`
+
+	// Membership selects and configures the backend that resolves the memberships of users.
+	// If not set, the MetalAPI backend is configured from the deprecated metal fields.
+	// The MetalV2 and Static backends are only supported by the fits-authn-webhook.
+	// +optional
+	Membership *MembershipBackend `json:"membership,omitempty"`
+
+	// ProjectTenant configures how the tenant is derived from the garden project of a shoot without tenant annotation.
+	// The tenant annotation of the shoot always takes precedence. If not set, shoots without tenant annotation cannot
+	// be reconciled.
+	// +optional
+	ProjectTenant *ProjectTenant `json:"projectTenant,omitempty"`
+}
+
+// ProjectTenant configures how the tenant is derived from the garden project of a shoot.
+type ProjectTenant struct {
+	// Label is the label of the project that holds the tenant, it takes precedence over the annotation.
+	// +optional
+	Label string `json:"label,omitempty"`
+	// Annotation is the annotation of the project that holds the tenant.
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
+	// CacheTTL is the duration for which the tenant of a project is cached, defaults to 5m.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+}
+
+// AuthProfile is a named auth configuration.
+type AuthProfile struct {
+	// Name is the name of the profile. The name default is reserved for the top-level auth configuration.
+	Name string `json:"name"`
+	// Tenants are the tenants whose shoots use this profile unless they select a profile explicitly.
+	// +optional
+	Tenants []string `json:"tenants,omitempty"`
+	// Auth is the auth configuration of the profile. The project tenant settings only apply to the default profile.
+	Auth `json:",inline"`
+}
+
+// MembershipBackendType is the type of a membership backend.
+type MembershipBackendType string
+
+const (
+	// MembershipBackendMetalAPI resolves memberships through the metal-api with hmac authentication.
+	MembershipBackendMetalAPI MembershipBackendType = "MetalAPI"
+	// MembershipBackendMetalV2 resolves memberships through the metal-stack v2 api with an api token.
+	MembershipBackendMetalV2 MembershipBackendType = "MetalV2"
+	// MembershipBackendStatic resolves memberships from a static mapping.
+	MembershipBackendStatic MembershipBackendType = "Static"
+	// MembershipBackendNone only uses the groups contained in the token.
+	MembershipBackendNone MembershipBackendType = "None"
+)
+
+// MembershipBackend selects and configures the backend that resolves the memberships of users.
+type MembershipBackend struct {
+	// Type is the type of the backend.
+	Type MembershipBackendType `json:"type"`
+	// MetalAPI contains the configuration for the MetalAPI backend.
+	// +optional
+	MetalAPI *MetalAPIMembership `json:"metalAPI,omitempty"`
+	// MetalV2 contains the configuration for the MetalV2 backend.
+	// +optional
+	MetalV2 *MetalV2Membership `json:"metalV2,omitempty"`
+	// Static contains the configuration for the Static backend.
+	// +optional
+	Static *StaticMembership `json:"static,omitempty"`
+}
+
+// MetalAPIMembership contains the configuration for the MetalAPI membership backend.
+type MetalAPIMembership struct {
+	// URL is the url of the metal-api.
+	URL string `json:"url"`
+	// HMAC is the hmac key for the metal-api.
+	HMAC string `json:"hmac"`
+	// AuthType is the hmac auth type, e.g. "Metal-View".
+	AuthType string `json:"authType"`
+}
+
+// MetalV2Membership contains the configuration for the MetalV2 membership backend.
+type MetalV2Membership struct {
+	// URL is the url of the metal-stack v2 api.
+	URL string `json:"url"`
+	// Token is the api token.
+	Token string `json:"token"`
+}
+
+// StaticMembership contains the configuration for the Static membership backend.
+type StaticMembership struct {
+	// Users maps user names to kubernetes groups.
+	// +optional
+	Users map[string][]string `json:"users,omitempty"`
+	// Groups maps token groups to kubernetes groups.
+	// +optional
+	Groups map[string][]string `json:"groups,omitempty"`
 }
 
 // ImagePullSecret provides an opportunity to inject an image pull secret into the resource deployments
@@ -38,3 +259,72 @@ type ImagePullSecret struct {
 	// DockerConfigJSON contains the already base64 encoded JSON content for the image pull secret
 	DockerConfigJSON string `json:"encodedDockerConfigJSON"`
 }
+
+// GroupRoleBindingControllerMode defines where the group rolebinding controller runs.
+type GroupRoleBindingControllerMode string
+
+const (
+	// GroupRoleBindingControllerModeDeployment runs the controller as a deployment in the shoot namespace of the seed.
+	GroupRoleBindingControllerModeDeployment GroupRoleBindingControllerMode = "Deployment"
+	// GroupRoleBindingControllerModeExtension runs the controller inside the extension process.
+	GroupRoleBindingControllerModeExtension GroupRoleBindingControllerMode = "Extension"
+)
+
+// GroupRoleBindingController contains the configuration for the controller that binds namespace groups to cluster roles.
+type GroupRoleBindingController struct {
+	// Mode defines where the controller runs, defaults to Deployment.
+	// +optional
+	Mode GroupRoleBindingControllerMode `json:"mode,omitempty"`
+	// ExcludeNamespaces are the namespaces in which no rolebindings are created.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// ExpectedGroups are the cluster roles that are bound to a group in every namespace.
+	// +optional
+	ExpectedGroups []string `json:"expectedGroups,omitempty"`
+	// SyncPeriod is the interval in which the rolebindings of a shoot are synced when running in the extension process.
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+}
+
+// ProjectMembers configures the synchronization of the garden project members into the shoot RBAC.
+// Members of kind User and Group are bound cluster wide to the cluster roles their project roles are mapped to,
+// so their names need to match the identities issued by the authenticator of the shoot.
+type ProjectMembers struct {
+	// RoleMapping maps the roles of the project members to the cluster roles that are bound in the shoot,
+	// defaults to "admin: cluster-admin" and "viewer: view". Roles that are not contained are ignored.
+	// +optional
+	RoleMapping map[string]string `json:"roleMapping,omitempty"`
+}
+
+// TokenWebhook contains the settings of the token webhook.
+// The cache TTL defaults to 2m, the timeout to 10s and no failed requests are retried by default.
+type TokenWebhook struct {
+	// CacheTTL is the duration for which the kube-apiserver caches the responses of the token webhook.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+	// Timeout is the timeout for authenticating a token including the requests to the issuer and the membership backend.
+	// The kube-apiserver gives up on the webhook after 30s, so the timeout must not exceed it. Defaults to 10s.
+	// It is only supported by the fits-authn-webhook.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// RetryBackoff defines how failed requests to the membership backend are retried. Retries are only supported
+	// by the fits-authn-webhook.
+	// +optional
+	RetryBackoff *RetryBackoff `json:"retryBackoff,omitempty"`
+	// ReadinessTimeout is the maximum duration the kube-apiserver waits for the token webhook to become reachable
+	// before it starts, such that early logins after a wake-up do not fail. The kube-apiserver starts anyway once
+	// the timeout is exceeded. The wait is disabled if the timeout is not set or 0s, which is the default. It is
+	// only supported by the fits-authn-webhook.
+	// +optional
+	ReadinessTimeout *metav1.Duration `json:"readinessTimeout,omitempty"`
+}
+
+// RetryBackoff defines how failed requests are retried.
+type RetryBackoff struct {
+	// InitialDelay is the delay before the first retry, it is doubled for every further retry.
+	// +optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+	// Retries is the maximum number of retries.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+}
@@ -14,6 +14,7 @@ import (
 
 	config "github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
 	configv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -35,6 +36,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AuthProfile)(nil), (*config.AuthProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AuthProfile_To_config_AuthProfile(a.(*AuthProfile), b.(*config.AuthProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AuthProfile)(nil), (*AuthProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AuthProfile_To_v1alpha1_AuthProfile(a.(*config.AuthProfile), b.(*AuthProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Authorization)(nil), (*config.Authorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Authorization_To_config_Authorization(a.(*Authorization), b.(*config.Authorization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Authorization)(nil), (*Authorization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Authorization_To_v1alpha1_Authorization(a.(*config.Authorization), b.(*Authorization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BreakGlass)(nil), (*config.BreakGlass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BreakGlass_To_config_BreakGlass(a.(*BreakGlass), b.(*config.BreakGlass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.BreakGlass)(nil), (*BreakGlass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_BreakGlass_To_v1alpha1_BreakGlass(a.(*config.BreakGlass), b.(*BreakGlass), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -45,6 +76,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GroupRoleBindingController)(nil), (*config.GroupRoleBindingController)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GroupRoleBindingController_To_config_GroupRoleBindingController(a.(*GroupRoleBindingController), b.(*config.GroupRoleBindingController), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.GroupRoleBindingController)(nil), (*GroupRoleBindingController)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_GroupRoleBindingController_To_v1alpha1_GroupRoleBindingController(a.(*config.GroupRoleBindingController), b.(*GroupRoleBindingController), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ImagePullSecret)(nil), (*config.ImagePullSecret)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ImagePullSecret_To_config_ImagePullSecret(a.(*ImagePullSecret), b.(*config.ImagePullSecret), scope)
 	}); err != nil {
@@ -55,14 +96,130 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ManagedResources)(nil), (*config.ManagedResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ManagedResources_To_config_ManagedResources(a.(*ManagedResources), b.(*config.ManagedResources), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ManagedResources)(nil), (*ManagedResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ManagedResources_To_v1alpha1_ManagedResources(a.(*config.ManagedResources), b.(*ManagedResources), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MembershipBackend)(nil), (*config.MembershipBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MembershipBackend_To_config_MembershipBackend(a.(*MembershipBackend), b.(*config.MembershipBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MembershipBackend)(nil), (*MembershipBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MembershipBackend_To_v1alpha1_MembershipBackend(a.(*config.MembershipBackend), b.(*MembershipBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MetalAPIMembership)(nil), (*config.MetalAPIMembership)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetalAPIMembership_To_config_MetalAPIMembership(a.(*MetalAPIMembership), b.(*config.MetalAPIMembership), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MetalAPIMembership)(nil), (*MetalAPIMembership)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MetalAPIMembership_To_v1alpha1_MetalAPIMembership(a.(*config.MetalAPIMembership), b.(*MetalAPIMembership), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MetalV2Membership)(nil), (*config.MetalV2Membership)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetalV2Membership_To_config_MetalV2Membership(a.(*MetalV2Membership), b.(*config.MetalV2Membership), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MetalV2Membership)(nil), (*MetalV2Membership)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MetalV2Membership_To_v1alpha1_MetalV2Membership(a.(*config.MetalV2Membership), b.(*MetalV2Membership), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OIDCKubeconfig)(nil), (*config.OIDCKubeconfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OIDCKubeconfig_To_config_OIDCKubeconfig(a.(*OIDCKubeconfig), b.(*config.OIDCKubeconfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.OIDCKubeconfig)(nil), (*OIDCKubeconfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_OIDCKubeconfig_To_v1alpha1_OIDCKubeconfig(a.(*config.OIDCKubeconfig), b.(*OIDCKubeconfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProjectMembers)(nil), (*config.ProjectMembers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProjectMembers_To_config_ProjectMembers(a.(*ProjectMembers), b.(*config.ProjectMembers), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ProjectMembers)(nil), (*ProjectMembers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ProjectMembers_To_v1alpha1_ProjectMembers(a.(*config.ProjectMembers), b.(*ProjectMembers), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProjectTenant)(nil), (*config.ProjectTenant)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProjectTenant_To_config_ProjectTenant(a.(*ProjectTenant), b.(*config.ProjectTenant), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ProjectTenant)(nil), (*ProjectTenant)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ProjectTenant_To_v1alpha1_ProjectTenant(a.(*config.ProjectTenant), b.(*ProjectTenant), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceRule)(nil), (*config.ResourceRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResourceRule_To_config_ResourceRule(a.(*ResourceRule), b.(*config.ResourceRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ResourceRule)(nil), (*ResourceRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ResourceRule_To_v1alpha1_ResourceRule(a.(*config.ResourceRule), b.(*ResourceRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RetryBackoff)(nil), (*config.RetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RetryBackoff_To_config_RetryBackoff(a.(*RetryBackoff), b.(*config.RetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RetryBackoff)(nil), (*RetryBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RetryBackoff_To_v1alpha1_RetryBackoff(a.(*config.RetryBackoff), b.(*RetryBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticMembership)(nil), (*config.StaticMembership)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StaticMembership_To_config_StaticMembership(a.(*StaticMembership), b.(*config.StaticMembership), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.StaticMembership)(nil), (*StaticMembership)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_StaticMembership_To_v1alpha1_StaticMembership(a.(*config.StaticMembership), b.(*StaticMembership), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TokenWebhook)(nil), (*config.TokenWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TokenWebhook_To_config_TokenWebhook(a.(*TokenWebhook), b.(*config.TokenWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TokenWebhook)(nil), (*TokenWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TokenWebhook_To_v1alpha1_TokenWebhook(a.(*config.TokenWebhook), b.(*TokenWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
 func autoConvert_v1alpha1_Auth_To_config_Auth(in *Auth, out *config.Auth, s conversion.Scope) error {
 	out.ProviderTenant = in.ProviderTenant
+	out.AuthenticatorImage = in.AuthenticatorImage
+	out.Issuer = in.Issuer
+	out.ClientID = in.ClientID
+	out.GroupsPrefix = in.GroupsPrefix
 	out.MetalURL = in.MetalURL
 	out.MetalHMAC = in.MetalHMAC
 	out.MetalAuthType = in.MetalAuthType
+	out.Membership = (*config.MembershipBackend)(unsafe.Pointer(in.Membership))
+	out.ProjectTenant = (*config.ProjectTenant)(unsafe.Pointer(in.ProjectTenant))
 	return nil
 }
 
@@ -73,9 +230,15 @@ func Convert_v1alpha1_Auth_To_config_Auth(in *Auth, out *config.Auth, s conversi
 
 func autoConvert_config_Auth_To_v1alpha1_Auth(in *config.Auth, out *Auth, s conversion.Scope) error {
 	out.ProviderTenant = in.ProviderTenant
+	out.AuthenticatorImage = in.AuthenticatorImage
+	out.Issuer = in.Issuer
+	out.ClientID = in.ClientID
+	out.GroupsPrefix = in.GroupsPrefix
 	out.MetalURL = in.MetalURL
 	out.MetalHMAC = in.MetalHMAC
 	out.MetalAuthType = in.MetalAuthType
+	out.Membership = (*MembershipBackend)(unsafe.Pointer(in.Membership))
+	out.ProjectTenant = (*ProjectTenant)(unsafe.Pointer(in.ProjectTenant))
 	return nil
 }
 
@@ -84,12 +247,92 @@ func Convert_config_Auth_To_v1alpha1_Auth(in *config.Auth, out *Auth, s conversi
 	return autoConvert_config_Auth_To_v1alpha1_Auth(in, out, s)
 }
 
+func autoConvert_v1alpha1_AuthProfile_To_config_AuthProfile(in *AuthProfile, out *config.AuthProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Tenants = *(*[]string)(unsafe.Pointer(&in.Tenants))
+	if err := Convert_v1alpha1_Auth_To_config_Auth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_AuthProfile_To_config_AuthProfile is an autogenerated conversion function.
+func Convert_v1alpha1_AuthProfile_To_config_AuthProfile(in *AuthProfile, out *config.AuthProfile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AuthProfile_To_config_AuthProfile(in, out, s)
+}
+
+func autoConvert_config_AuthProfile_To_v1alpha1_AuthProfile(in *config.AuthProfile, out *AuthProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Tenants = *(*[]string)(unsafe.Pointer(&in.Tenants))
+	if err := Convert_config_Auth_To_v1alpha1_Auth(&in.Auth, &out.Auth, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_AuthProfile_To_v1alpha1_AuthProfile is an autogenerated conversion function.
+func Convert_config_AuthProfile_To_v1alpha1_AuthProfile(in *config.AuthProfile, out *AuthProfile, s conversion.Scope) error {
+	return autoConvert_config_AuthProfile_To_v1alpha1_AuthProfile(in, out, s)
+}
+
+func autoConvert_v1alpha1_Authorization_To_config_Authorization(in *Authorization, out *config.Authorization, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.ProviderTenantDeny = *(*[]config.ResourceRule)(unsafe.Pointer(&in.ProviderTenantDeny))
+	out.FailurePolicy = config.AuthorizationFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_v1alpha1_Authorization_To_config_Authorization is an autogenerated conversion function.
+func Convert_v1alpha1_Authorization_To_config_Authorization(in *Authorization, out *config.Authorization, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Authorization_To_config_Authorization(in, out, s)
+}
+
+func autoConvert_config_Authorization_To_v1alpha1_Authorization(in *config.Authorization, out *Authorization, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.ProviderTenantDeny = *(*[]ResourceRule)(unsafe.Pointer(&in.ProviderTenantDeny))
+	out.FailurePolicy = AuthorizationFailurePolicy(in.FailurePolicy)
+	return nil
+}
+
+// Convert_config_Authorization_To_v1alpha1_Authorization is an autogenerated conversion function.
+func Convert_config_Authorization_To_v1alpha1_Authorization(in *config.Authorization, out *Authorization, s conversion.Scope) error {
+	return autoConvert_config_Authorization_To_v1alpha1_Authorization(in, out, s)
+}
+
+func autoConvert_v1alpha1_BreakGlass_To_config_BreakGlass(in *BreakGlass, out *config.BreakGlass, s conversion.Scope) error {
+	out.GardenNamespace = in.GardenNamespace
+	return nil
+}
+
+// Convert_v1alpha1_BreakGlass_To_config_BreakGlass is an autogenerated conversion function.
+func Convert_v1alpha1_BreakGlass_To_config_BreakGlass(in *BreakGlass, out *config.BreakGlass, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BreakGlass_To_config_BreakGlass(in, out, s)
+}
+
+func autoConvert_config_BreakGlass_To_v1alpha1_BreakGlass(in *config.BreakGlass, out *BreakGlass, s conversion.Scope) error {
+	out.GardenNamespace = in.GardenNamespace
+	return nil
+}
+
+// Convert_config_BreakGlass_To_v1alpha1_BreakGlass is an autogenerated conversion function.
+func Convert_config_BreakGlass_To_v1alpha1_BreakGlass(in *config.BreakGlass, out *BreakGlass, s conversion.Scope) error {
+	return autoConvert_config_BreakGlass_To_v1alpha1_BreakGlass(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	if err := Convert_v1alpha1_Auth_To_config_Auth(&in.Auth, &out.Auth, s); err != nil {
 		return err
 	}
+	out.Profiles = *(*[]config.AuthProfile)(unsafe.Pointer(&in.Profiles))
 	out.HealthCheckConfig = (*configv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.ImagePullSecret = (*config.ImagePullSecret)(unsafe.Pointer(in.ImagePullSecret))
+	out.GroupRoleBindingController = (*config.GroupRoleBindingController)(unsafe.Pointer(in.GroupRoleBindingController))
+	out.ProjectMembers = (*config.ProjectMembers)(unsafe.Pointer(in.ProjectMembers))
+	out.TokenWebhook = (*config.TokenWebhook)(unsafe.Pointer(in.TokenWebhook))
+	out.Authorization = (*config.Authorization)(unsafe.Pointer(in.Authorization))
+	out.BreakGlass = (*config.BreakGlass)(unsafe.Pointer(in.BreakGlass))
+	out.OIDCKubeconfig = (*config.OIDCKubeconfig)(unsafe.Pointer(in.OIDCKubeconfig))
+	out.ManagedResources = (*config.ManagedResources)(unsafe.Pointer(in.ManagedResources))
 	return nil
 }
 
@@ -102,8 +345,16 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 	if err := Convert_config_Auth_To_v1alpha1_Auth(&in.Auth, &out.Auth, s); err != nil {
 		return err
 	}
+	out.Profiles = *(*[]AuthProfile)(unsafe.Pointer(&in.Profiles))
 	out.HealthCheckConfig = (*configv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.ImagePullSecret = (*ImagePullSecret)(unsafe.Pointer(in.ImagePullSecret))
+	out.GroupRoleBindingController = (*GroupRoleBindingController)(unsafe.Pointer(in.GroupRoleBindingController))
+	out.ProjectMembers = (*ProjectMembers)(unsafe.Pointer(in.ProjectMembers))
+	out.TokenWebhook = (*TokenWebhook)(unsafe.Pointer(in.TokenWebhook))
+	out.Authorization = (*Authorization)(unsafe.Pointer(in.Authorization))
+	out.BreakGlass = (*BreakGlass)(unsafe.Pointer(in.BreakGlass))
+	out.OIDCKubeconfig = (*OIDCKubeconfig)(unsafe.Pointer(in.OIDCKubeconfig))
+	out.ManagedResources = (*ManagedResources)(unsafe.Pointer(in.ManagedResources))
 	return nil
 }
 
@@ -112,6 +363,32 @@ func Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(
 	return autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in, out, s)
 }
 
+func autoConvert_v1alpha1_GroupRoleBindingController_To_config_GroupRoleBindingController(in *GroupRoleBindingController, out *config.GroupRoleBindingController, s conversion.Scope) error {
+	out.Mode = config.GroupRoleBindingControllerMode(in.Mode)
+	out.ExcludeNamespaces = *(*[]string)(unsafe.Pointer(&in.ExcludeNamespaces))
+	out.ExpectedGroups = *(*[]string)(unsafe.Pointer(&in.ExpectedGroups))
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	return nil
+}
+
+// Convert_v1alpha1_GroupRoleBindingController_To_config_GroupRoleBindingController is an autogenerated conversion function.
+func Convert_v1alpha1_GroupRoleBindingController_To_config_GroupRoleBindingController(in *GroupRoleBindingController, out *config.GroupRoleBindingController, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GroupRoleBindingController_To_config_GroupRoleBindingController(in, out, s)
+}
+
+func autoConvert_config_GroupRoleBindingController_To_v1alpha1_GroupRoleBindingController(in *config.GroupRoleBindingController, out *GroupRoleBindingController, s conversion.Scope) error {
+	out.Mode = GroupRoleBindingControllerMode(in.Mode)
+	out.ExcludeNamespaces = *(*[]string)(unsafe.Pointer(&in.ExcludeNamespaces))
+	out.ExpectedGroups = *(*[]string)(unsafe.Pointer(&in.ExpectedGroups))
+	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
+	return nil
+}
+
+// Convert_config_GroupRoleBindingController_To_v1alpha1_GroupRoleBindingController is an autogenerated conversion function.
+func Convert_config_GroupRoleBindingController_To_v1alpha1_GroupRoleBindingController(in *config.GroupRoleBindingController, out *GroupRoleBindingController, s conversion.Scope) error {
+	return autoConvert_config_GroupRoleBindingController_To_v1alpha1_GroupRoleBindingController(in, out, s)
+}
+
 func autoConvert_v1alpha1_ImagePullSecret_To_config_ImagePullSecret(in *ImagePullSecret, out *config.ImagePullSecret, s conversion.Scope) error {
 	out.DockerConfigJSON = in.DockerConfigJSON
 	return nil
@@ -131,3 +408,253 @@ func autoConvert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in *config.I
 func Convert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in *config.ImagePullSecret, out *ImagePullSecret, s conversion.Scope) error {
 	return autoConvert_config_ImagePullSecret_To_v1alpha1_ImagePullSecret(in, out, s)
 }
+
+func autoConvert_v1alpha1_ManagedResources_To_config_ManagedResources(in *ManagedResources, out *config.ManagedResources, s conversion.Scope) error {
+	out.HealthTimeout = (*v1.Duration)(unsafe.Pointer(in.HealthTimeout))
+	out.DeletionTimeout = (*v1.Duration)(unsafe.Pointer(in.DeletionTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_ManagedResources_To_config_ManagedResources is an autogenerated conversion function.
+func Convert_v1alpha1_ManagedResources_To_config_ManagedResources(in *ManagedResources, out *config.ManagedResources, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ManagedResources_To_config_ManagedResources(in, out, s)
+}
+
+func autoConvert_config_ManagedResources_To_v1alpha1_ManagedResources(in *config.ManagedResources, out *ManagedResources, s conversion.Scope) error {
+	out.HealthTimeout = (*v1.Duration)(unsafe.Pointer(in.HealthTimeout))
+	out.DeletionTimeout = (*v1.Duration)(unsafe.Pointer(in.DeletionTimeout))
+	return nil
+}
+
+// Convert_config_ManagedResources_To_v1alpha1_ManagedResources is an autogenerated conversion function.
+func Convert_config_ManagedResources_To_v1alpha1_ManagedResources(in *config.ManagedResources, out *ManagedResources, s conversion.Scope) error {
+	return autoConvert_config_ManagedResources_To_v1alpha1_ManagedResources(in, out, s)
+}
+
+func autoConvert_v1alpha1_MembershipBackend_To_config_MembershipBackend(in *MembershipBackend, out *config.MembershipBackend, s conversion.Scope) error {
+	out.Type = config.MembershipBackendType(in.Type)
+	out.MetalAPI = (*config.MetalAPIMembership)(unsafe.Pointer(in.MetalAPI))
+	out.MetalV2 = (*config.MetalV2Membership)(unsafe.Pointer(in.MetalV2))
+	out.Static = (*config.StaticMembership)(unsafe.Pointer(in.Static))
+	return nil
+}
+
+// Convert_v1alpha1_MembershipBackend_To_config_MembershipBackend is an autogenerated conversion function.
+func Convert_v1alpha1_MembershipBackend_To_config_MembershipBackend(in *MembershipBackend, out *config.MembershipBackend, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MembershipBackend_To_config_MembershipBackend(in, out, s)
+}
+
+func autoConvert_config_MembershipBackend_To_v1alpha1_MembershipBackend(in *config.MembershipBackend, out *MembershipBackend, s conversion.Scope) error {
+	out.Type = MembershipBackendType(in.Type)
+	out.MetalAPI = (*MetalAPIMembership)(unsafe.Pointer(in.MetalAPI))
+	out.MetalV2 = (*MetalV2Membership)(unsafe.Pointer(in.MetalV2))
+	out.Static = (*StaticMembership)(unsafe.Pointer(in.Static))
+	return nil
+}
+
+// Convert_config_MembershipBackend_To_v1alpha1_MembershipBackend is an autogenerated conversion function.
+func Convert_config_MembershipBackend_To_v1alpha1_MembershipBackend(in *config.MembershipBackend, out *MembershipBackend, s conversion.Scope) error {
+	return autoConvert_config_MembershipBackend_To_v1alpha1_MembershipBackend(in, out, s)
+}
+
+func autoConvert_v1alpha1_MetalAPIMembership_To_config_MetalAPIMembership(in *MetalAPIMembership, out *config.MetalAPIMembership, s conversion.Scope) error {
+	out.URL = in.URL
+	out.HMAC = in.HMAC
+	out.AuthType = in.AuthType
+	return nil
+}
+
+// Convert_v1alpha1_MetalAPIMembership_To_config_MetalAPIMembership is an autogenerated conversion function.
+func Convert_v1alpha1_MetalAPIMembership_To_config_MetalAPIMembership(in *MetalAPIMembership, out *config.MetalAPIMembership, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetalAPIMembership_To_config_MetalAPIMembership(in, out, s)
+}
+
+func autoConvert_config_MetalAPIMembership_To_v1alpha1_MetalAPIMembership(in *config.MetalAPIMembership, out *MetalAPIMembership, s conversion.Scope) error {
+	out.URL = in.URL
+	out.HMAC = in.HMAC
+	out.AuthType = in.AuthType
+	return nil
+}
+
+// Convert_config_MetalAPIMembership_To_v1alpha1_MetalAPIMembership is an autogenerated conversion function.
+func Convert_config_MetalAPIMembership_To_v1alpha1_MetalAPIMembership(in *config.MetalAPIMembership, out *MetalAPIMembership, s conversion.Scope) error {
+	return autoConvert_config_MetalAPIMembership_To_v1alpha1_MetalAPIMembership(in, out, s)
+}
+
+func autoConvert_v1alpha1_MetalV2Membership_To_config_MetalV2Membership(in *MetalV2Membership, out *config.MetalV2Membership, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Token = in.Token
+	return nil
+}
+
+// Convert_v1alpha1_MetalV2Membership_To_config_MetalV2Membership is an autogenerated conversion function.
+func Convert_v1alpha1_MetalV2Membership_To_config_MetalV2Membership(in *MetalV2Membership, out *config.MetalV2Membership, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetalV2Membership_To_config_MetalV2Membership(in, out, s)
+}
+
+func autoConvert_config_MetalV2Membership_To_v1alpha1_MetalV2Membership(in *config.MetalV2Membership, out *MetalV2Membership, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Token = in.Token
+	return nil
+}
+
+// Convert_config_MetalV2Membership_To_v1alpha1_MetalV2Membership is an autogenerated conversion function.
+func Convert_config_MetalV2Membership_To_v1alpha1_MetalV2Membership(in *config.MetalV2Membership, out *MetalV2Membership, s conversion.Scope) error {
+	return autoConvert_config_MetalV2Membership_To_v1alpha1_MetalV2Membership(in, out, s)
+}
+
+func autoConvert_v1alpha1_OIDCKubeconfig_To_config_OIDCKubeconfig(in *OIDCKubeconfig, out *config.OIDCKubeconfig, s conversion.Scope) error {
+	out.ExtraScopes = *(*[]string)(unsafe.Pointer(&in.ExtraScopes))
+	return nil
+}
+
+// Convert_v1alpha1_OIDCKubeconfig_To_config_OIDCKubeconfig is an autogenerated conversion function.
+func Convert_v1alpha1_OIDCKubeconfig_To_config_OIDCKubeconfig(in *OIDCKubeconfig, out *config.OIDCKubeconfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OIDCKubeconfig_To_config_OIDCKubeconfig(in, out, s)
+}
+
+func autoConvert_config_OIDCKubeconfig_To_v1alpha1_OIDCKubeconfig(in *config.OIDCKubeconfig, out *OIDCKubeconfig, s conversion.Scope) error {
+	out.ExtraScopes = *(*[]string)(unsafe.Pointer(&in.ExtraScopes))
+	return nil
+}
+
+// Convert_config_OIDCKubeconfig_To_v1alpha1_OIDCKubeconfig is an autogenerated conversion function.
+func Convert_config_OIDCKubeconfig_To_v1alpha1_OIDCKubeconfig(in *config.OIDCKubeconfig, out *OIDCKubeconfig, s conversion.Scope) error {
+	return autoConvert_config_OIDCKubeconfig_To_v1alpha1_OIDCKubeconfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProjectMembers_To_config_ProjectMembers(in *ProjectMembers, out *config.ProjectMembers, s conversion.Scope) error {
+	out.RoleMapping = *(*map[string]string)(unsafe.Pointer(&in.RoleMapping))
+	return nil
+}
+
+// Convert_v1alpha1_ProjectMembers_To_config_ProjectMembers is an autogenerated conversion function.
+func Convert_v1alpha1_ProjectMembers_To_config_ProjectMembers(in *ProjectMembers, out *config.ProjectMembers, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProjectMembers_To_config_ProjectMembers(in, out, s)
+}
+
+func autoConvert_config_ProjectMembers_To_v1alpha1_ProjectMembers(in *config.ProjectMembers, out *ProjectMembers, s conversion.Scope) error {
+	out.RoleMapping = *(*map[string]string)(unsafe.Pointer(&in.RoleMapping))
+	return nil
+}
+
+// Convert_config_ProjectMembers_To_v1alpha1_ProjectMembers is an autogenerated conversion function.
+func Convert_config_ProjectMembers_To_v1alpha1_ProjectMembers(in *config.ProjectMembers, out *ProjectMembers, s conversion.Scope) error {
+	return autoConvert_config_ProjectMembers_To_v1alpha1_ProjectMembers(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProjectTenant_To_config_ProjectTenant(in *ProjectTenant, out *config.ProjectTenant, s conversion.Scope) error {
+	out.Label = in.Label
+	out.Annotation = in.Annotation
+	out.CacheTTL = (*v1.Duration)(unsafe.Pointer(in.CacheTTL))
+	return nil
+}
+
+// Convert_v1alpha1_ProjectTenant_To_config_ProjectTenant is an autogenerated conversion function.
+func Convert_v1alpha1_ProjectTenant_To_config_ProjectTenant(in *ProjectTenant, out *config.ProjectTenant, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProjectTenant_To_config_ProjectTenant(in, out, s)
+}
+
+func autoConvert_config_ProjectTenant_To_v1alpha1_ProjectTenant(in *config.ProjectTenant, out *ProjectTenant, s conversion.Scope) error {
+	out.Label = in.Label
+	out.Annotation = in.Annotation
+	out.CacheTTL = (*v1.Duration)(unsafe.Pointer(in.CacheTTL))
+	return nil
+}
+
+// Convert_config_ProjectTenant_To_v1alpha1_ProjectTenant is an autogenerated conversion function.
+func Convert_config_ProjectTenant_To_v1alpha1_ProjectTenant(in *config.ProjectTenant, out *ProjectTenant, s conversion.Scope) error {
+	return autoConvert_config_ProjectTenant_To_v1alpha1_ProjectTenant(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResourceRule_To_config_ResourceRule(in *ResourceRule, out *config.ResourceRule, s conversion.Scope) error {
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	out.Resources = *(*[]string)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_v1alpha1_ResourceRule_To_config_ResourceRule is an autogenerated conversion function.
+func Convert_v1alpha1_ResourceRule_To_config_ResourceRule(in *ResourceRule, out *config.ResourceRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResourceRule_To_config_ResourceRule(in, out, s)
+}
+
+func autoConvert_config_ResourceRule_To_v1alpha1_ResourceRule(in *config.ResourceRule, out *ResourceRule, s conversion.Scope) error {
+	out.Verbs = *(*[]string)(unsafe.Pointer(&in.Verbs))
+	out.Resources = *(*[]string)(unsafe.Pointer(&in.Resources))
+	return nil
+}
+
+// Convert_config_ResourceRule_To_v1alpha1_ResourceRule is an autogenerated conversion function.
+func Convert_config_ResourceRule_To_v1alpha1_ResourceRule(in *config.ResourceRule, out *ResourceRule, s conversion.Scope) error {
+	return autoConvert_config_ResourceRule_To_v1alpha1_ResourceRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_RetryBackoff_To_config_RetryBackoff(in *RetryBackoff, out *config.RetryBackoff, s conversion.Scope) error {
+	out.InitialDelay = (*v1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	return nil
+}
+
+// Convert_v1alpha1_RetryBackoff_To_config_RetryBackoff is an autogenerated conversion function.
+func Convert_v1alpha1_RetryBackoff_To_config_RetryBackoff(in *RetryBackoff, out *config.RetryBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RetryBackoff_To_config_RetryBackoff(in, out, s)
+}
+
+func autoConvert_config_RetryBackoff_To_v1alpha1_RetryBackoff(in *config.RetryBackoff, out *RetryBackoff, s conversion.Scope) error {
+	out.InitialDelay = (*v1.Duration)(unsafe.Pointer(in.InitialDelay))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	return nil
+}
+
+// Convert_config_RetryBackoff_To_v1alpha1_RetryBackoff is an autogenerated conversion function.
+func Convert_config_RetryBackoff_To_v1alpha1_RetryBackoff(in *config.RetryBackoff, out *RetryBackoff, s conversion.Scope) error {
+	return autoConvert_config_RetryBackoff_To_v1alpha1_RetryBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha1_StaticMembership_To_config_StaticMembership(in *StaticMembership, out *config.StaticMembership, s conversion.Scope) error {
+	out.Users = *(*map[string][]string)(unsafe.Pointer(&in.Users))
+	out.Groups = *(*map[string][]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_v1alpha1_StaticMembership_To_config_StaticMembership is an autogenerated conversion function.
+func Convert_v1alpha1_StaticMembership_To_config_StaticMembership(in *StaticMembership, out *config.StaticMembership, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StaticMembership_To_config_StaticMembership(in, out, s)
+}
+
+func autoConvert_config_StaticMembership_To_v1alpha1_StaticMembership(in *config.StaticMembership, out *StaticMembership, s conversion.Scope) error {
+	out.Users = *(*map[string][]string)(unsafe.Pointer(&in.Users))
+	out.Groups = *(*map[string][]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_config_StaticMembership_To_v1alpha1_StaticMembership is an autogenerated conversion function.
+func Convert_config_StaticMembership_To_v1alpha1_StaticMembership(in *config.StaticMembership, out *StaticMembership, s conversion.Scope) error {
+	return autoConvert_config_StaticMembership_To_v1alpha1_StaticMembership(in, out, s)
+}
+
+func autoConvert_v1alpha1_TokenWebhook_To_config_TokenWebhook(in *TokenWebhook, out *config.TokenWebhook, s conversion.Scope) error {
+	out.CacheTTL = (*v1.Duration)(unsafe.Pointer(in.CacheTTL))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RetryBackoff = (*config.RetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	out.ReadinessTimeout = (*v1.Duration)(unsafe.Pointer(in.ReadinessTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_TokenWebhook_To_config_TokenWebhook is an autogenerated conversion function.
+func Convert_v1alpha1_TokenWebhook_To_config_TokenWebhook(in *TokenWebhook, out *config.TokenWebhook, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TokenWebhook_To_config_TokenWebhook(in, out, s)
+}
+
+func autoConvert_config_TokenWebhook_To_v1alpha1_TokenWebhook(in *config.TokenWebhook, out *TokenWebhook, s conversion.Scope) error {
+	out.CacheTTL = (*v1.Duration)(unsafe.Pointer(in.CacheTTL))
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.RetryBackoff = (*RetryBackoff)(unsafe.Pointer(in.RetryBackoff))
+	out.ReadinessTimeout = (*v1.Duration)(unsafe.Pointer(in.ReadinessTimeout))
+	return nil
+}
+
+// Convert_config_TokenWebhook_To_v1alpha1_TokenWebhook is an autogenerated conversion function.
+func Convert_config_TokenWebhook_To_v1alpha1_TokenWebhook(in *config.TokenWebhook, out *TokenWebhook, s conversion.Scope) error {
+	return autoConvert_config_TokenWebhook_To_v1alpha1_TokenWebhook(in, out, s)
+}
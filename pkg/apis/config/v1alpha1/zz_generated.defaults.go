@@ -17,5 +17,42 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ControllerConfiguration{}, func(obj interface{}) { SetObjectDefaults_ControllerConfiguration(obj.(*ControllerConfiguration)) })
 	return nil
 }
+
+func SetObjectDefaults_ControllerConfiguration(in *ControllerConfiguration) {
+	SetDefaults_ControllerConfiguration(in)
+	SetDefaults_Auth(&in.Auth)
+	if in.Auth.ProjectTenant != nil {
+		SetDefaults_ProjectTenant(in.Auth.ProjectTenant)
+	}
+	for i := range in.Profiles {
+		a := &in.Profiles[i]
+		SetDefaults_Auth(&a.Auth)
+		if a.Auth.ProjectTenant != nil {
+			SetDefaults_ProjectTenant(a.Auth.ProjectTenant)
+		}
+	}
+	if in.GroupRoleBindingController != nil {
+		SetDefaults_GroupRoleBindingController(in.GroupRoleBindingController)
+	}
+	if in.ProjectMembers != nil {
+		SetDefaults_ProjectMembers(in.ProjectMembers)
+	}
+	if in.TokenWebhook != nil {
+		SetDefaults_TokenWebhook(in.TokenWebhook)
+		if in.TokenWebhook.RetryBackoff != nil {
+			SetDefaults_RetryBackoff(in.TokenWebhook.RetryBackoff)
+		}
+	}
+	if in.Authorization != nil {
+		SetDefaults_Authorization(in.Authorization)
+	}
+	if in.OIDCKubeconfig != nil {
+		SetDefaults_OIDCKubeconfig(in.OIDCKubeconfig)
+	}
+	if in.ManagedResources != nil {
+		SetDefaults_ManagedResources(in.ManagedResources)
+	}
+}
@@ -0,0 +1,340 @@
+package validation
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+var supportedGroupRoleBindingControllerModes = []string{
+	string(config.GroupRoleBindingControllerModeDeployment),
+	string(config.GroupRoleBindingControllerModeExtension),
+}
+
+// ValidateConfiguration validates the passed configuration instance.
+func ValidateConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateAuth(&cfg.Auth, field.NewPath("auth"))...)
+	allErrs = append(allErrs, validateProfiles(cfg.Profiles, field.NewPath("profiles"))...)
+	allErrs = append(allErrs, validateTokenWebhook(cfg.TokenWebhook, field.NewPath("tokenWebhook"))...)
+	if tw := cfg.TokenWebhook; tw != nil && (tw.Timeout != nil || (tw.RetryBackoff != nil && ptr.Deref(tw.RetryBackoff.Retries, 0) > 0)) {
+		// the timeout and the retries are implemented by the authenticator built from this repository
+		allErrs = append(allErrs, validateFitsAuthenticatorImages(cfg, "the timeout or retries of the token webhook are configured")...)
+	}
+	if tw := cfg.TokenWebhook; tw != nil && tw.ReadinessTimeout != nil && tw.ReadinessTimeout.Duration > 0 {
+		// the kube-apiserver waits for the health endpoint, which is served by the authenticator built from this repository
+		allErrs = append(allErrs, validateFitsAuthenticatorImages(cfg, "the readiness timeout of the token webhook is configured")...)
+	}
+	allErrs = append(allErrs, validateAuthorization(cfg, field.NewPath("authorization"))...)
+	allErrs = append(allErrs, validateBreakGlass(cfg, field.NewPath("breakGlass"))...)
+
+	if grc := cfg.GroupRoleBindingController; grc != nil {
+		fldPath := field.NewPath("groupRoleBindingController")
+
+		switch grc.Mode {
+		case config.GroupRoleBindingControllerModeDeployment, config.GroupRoleBindingControllerModeExtension:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), grc.Mode, supportedGroupRoleBindingControllerModes))
+		}
+
+		if grc.SyncPeriod != nil && grc.SyncPeriod.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("syncPeriod"), grc.SyncPeriod.Duration.String(), "must be positive"))
+		}
+	}
+
+	if mr := cfg.ManagedResources; mr != nil {
+		if mr.HealthTimeout != nil && mr.HealthTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("managedResources", "healthTimeout"), mr.HealthTimeout.Duration.String(), "must be positive"))
+		}
+		if mr.DeletionTimeout != nil && mr.DeletionTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("managedResources", "deletionTimeout"), mr.DeletionTimeout.Duration.String(), "must be positive"))
+		}
+	}
+
+	if pm := cfg.ProjectMembers; pm != nil {
+		fldPath := field.NewPath("projectMembers", "roleMapping")
+
+		for _, role := range slices.Sorted(maps.Keys(pm.RoleMapping)) {
+			if pm.RoleMapping[role] == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Key(role), "cluster role must be set"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateProfiles(profiles []config.AuthProfile, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var (
+		names   = sets.New[string]()
+		tenants = sets.New[string]()
+	)
+
+	for i, profile := range profiles {
+		idxPath := fldPath.Index(i)
+
+		switch {
+		case profile.Name == "":
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "profile name must be set"))
+		case profile.Name == config.DefaultProfileName:
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("name"), "profile name "+config.DefaultProfileName+" is reserved for the top-level auth configuration"))
+		case names.Has(profile.Name):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), profile.Name))
+		}
+		names.Insert(profile.Name)
+
+		for j, tenant := range profile.Tenants {
+			tenant = strings.ToLower(tenant)
+			if tenants.Has(tenant) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("tenants").Index(j), tenant))
+			}
+			tenants.Insert(tenant)
+		}
+
+		allErrs = append(allErrs, validateAuth(&profile.Auth, idxPath)...)
+
+		if profile.ProjectTenant != nil {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("projectTenant"), "project tenant can only be configured in the default profile"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAuth(auth *config.Auth, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if auth.ProviderTenant == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("providerTenant"), "provider tenant must be set"))
+	}
+
+	allErrs = append(allErrs, ValidateMembershipBackend(auth.Membership, fldPath.Child("membership"))...)
+	if m := auth.Membership; m != nil && (m.Type == config.MembershipBackendMetalV2 || m.Type == config.MembershipBackendStatic) && auth.AuthenticatorImage != imagevector.ImageNameFitsAuthnWebhook {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("membership", "type"), m.Type, "requires the authenticator image "+imagevector.ImageNameFitsAuthnWebhook))
+	}
+
+	if pt := auth.ProjectTenant; pt != nil {
+		p := fldPath.Child("projectTenant")
+
+		if pt.Label == "" && pt.Annotation == "" {
+			allErrs = append(allErrs, field.Required(p, "label or annotation must be set"))
+		}
+		if pt.Label != "" {
+			allErrs = append(allErrs, metav1validation.ValidateLabelName(pt.Label, p.Child("label"))...)
+		}
+		if pt.Annotation != "" {
+			for _, msg := range validation.IsQualifiedName(pt.Annotation) {
+				allErrs = append(allErrs, field.Invalid(p.Child("annotation"), pt.Annotation, msg))
+			}
+		}
+		if pt.CacheTTL != nil && pt.CacheTTL.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(p.Child("cacheTTL"), pt.CacheTTL.Duration.String(), "must not be negative"))
+		}
+	}
+
+	return allErrs
+}
+
+var supportedMembershipBackends = []string{
+	string(config.MembershipBackendMetalAPI),
+	string(config.MembershipBackendMetalV2),
+	string(config.MembershipBackendStatic),
+	string(config.MembershipBackendNone),
+}
+
+// ValidateMembershipBackend validates the given membership backend.
+func ValidateMembershipBackend(backend *config.MembershipBackend, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if backend == nil {
+		return append(allErrs, field.Required(fldPath, "membership backend must be configured"))
+	}
+
+	for _, c := range []struct {
+		backendType config.MembershipBackendType
+		fieldName   string
+		configured  bool
+	}{
+		{config.MembershipBackendMetalAPI, "metalAPI", backend.MetalAPI != nil},
+		{config.MembershipBackendMetalV2, "metalV2", backend.MetalV2 != nil},
+		{config.MembershipBackendStatic, "static", backend.Static != nil},
+	} {
+		if c.configured && c.backendType != backend.Type {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(c.fieldName), "must not be set when using type "+string(backend.Type)))
+		}
+	}
+
+	switch backend.Type {
+	case config.MembershipBackendMetalAPI:
+		p := fldPath.Child("metalAPI")
+		if backend.MetalAPI == nil {
+			return append(allErrs, field.Required(p, "metal-api configuration must be set"))
+		}
+		if backend.MetalAPI.URL == "" {
+			allErrs = append(allErrs, field.Required(p.Child("url"), "url must be set"))
+		}
+		if backend.MetalAPI.HMAC == "" {
+			allErrs = append(allErrs, field.Required(p.Child("hmac"), "hmac must be set"))
+		}
+		if backend.MetalAPI.AuthType == "" {
+			allErrs = append(allErrs, field.Required(p.Child("authType"), "auth type must be set"))
+		}
+	case config.MembershipBackendMetalV2:
+		p := fldPath.Child("metalV2")
+		if backend.MetalV2 == nil {
+			return append(allErrs, field.Required(p, "metal-stack v2 configuration must be set"))
+		}
+		if backend.MetalV2.URL == "" {
+			allErrs = append(allErrs, field.Required(p.Child("url"), "url must be set"))
+		}
+		if backend.MetalV2.Token == "" {
+			allErrs = append(allErrs, field.Required(p.Child("token"), "token must be set"))
+		}
+	case config.MembershipBackendStatic:
+		p := fldPath.Child("static")
+		if backend.Static == nil {
+			return append(allErrs, field.Required(p, "static mapping must be set"))
+		}
+		for user, groups := range backend.Static.Users {
+			if len(groups) == 0 {
+				allErrs = append(allErrs, field.Required(p.Child("users").Key(user), "at least one group must be mapped"))
+			}
+		}
+		for group, groups := range backend.Static.Groups {
+			if len(groups) == 0 {
+				allErrs = append(allErrs, field.Required(p.Child("groups").Key(group), "at least one group must be mapped"))
+			}
+		}
+	case config.MembershipBackendNone:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), backend.Type, supportedMembershipBackends))
+	}
+
+	return allErrs
+}
+
+// maxTokenWebhookTimeout is the timeout after which the kube-apiserver gives up on the token webhook.
+const maxTokenWebhookTimeout = 30 * time.Second
+
+func validateTokenWebhook(tw *config.TokenWebhook, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tw == nil {
+		return allErrs
+	}
+
+	if tw.CacheTTL != nil && tw.CacheTTL.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cacheTTL"), tw.CacheTTL.Duration.String(), "must not be negative"))
+	}
+	if tw.Timeout != nil && (tw.Timeout.Duration <= 0 || tw.Timeout.Duration > maxTokenWebhookTimeout) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), tw.Timeout.Duration.String(), "must be positive and at most "+maxTokenWebhookTimeout.String()))
+	}
+	if tw.ReadinessTimeout != nil && tw.ReadinessTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("readinessTimeout"), tw.ReadinessTimeout.Duration.String(), "must not be negative"))
+	}
+
+	if rb := tw.RetryBackoff; rb != nil {
+		if rb.InitialDelay != nil && rb.InitialDelay.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("retryBackoff", "initialDelay"), rb.InitialDelay.Duration.String(), "must be positive"))
+		}
+		if rb.Retries != nil && (*rb.Retries < 0 || *rb.Retries > 10) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("retryBackoff", "retries"), *rb.Retries, "must be between 0 and 10"))
+		}
+	}
+
+	return allErrs
+}
+
+var supportedAuthorizationFailurePolicies = []string{
+	string(config.AuthorizationFailurePolicyDeny),
+	string(config.AuthorizationFailurePolicyNoOpinion),
+}
+
+func validateAuthorization(cfg *config.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	authz := cfg.Authorization
+	if authz == nil {
+		return allErrs
+	}
+
+	for i, rule := range authz.ProviderTenantDeny {
+		idxPath := fldPath.Child("providerTenantDeny").Index(i)
+		if len(rule.Verbs) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("verbs"), "at least one verb must be set"))
+		}
+		if len(rule.Resources) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("resources"), "at least one resource must be set"))
+		}
+	}
+
+	switch authz.FailurePolicy {
+	case config.AuthorizationFailurePolicyDeny, config.AuthorizationFailurePolicyNoOpinion:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("failurePolicy"), authz.FailurePolicy, supportedAuthorizationFailurePolicies))
+	}
+
+	if !authz.Enabled {
+		return allErrs
+	}
+
+	// the authorization endpoint is only served by the authenticator built from this repository
+	allErrs = append(allErrs, validateFitsAuthenticatorImages(cfg, "authorization is enabled")...)
+
+	return allErrs
+}
+
+func validateBreakGlass(cfg *config.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	bg := cfg.BreakGlass
+	if bg == nil {
+		return allErrs
+	}
+
+	if bg.GardenNamespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("gardenNamespace"), "garden namespace must be set"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(bg.GardenNamespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gardenNamespace"), bg.GardenNamespace, msg))
+		}
+		// project members can read the secrets of their project namespace, the credentials would be exposed to them
+		if bg.GardenNamespace == v1beta1constants.GardenNamespace || strings.HasPrefix(bg.GardenNamespace, v1beta1constants.GardenNamespace+"-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gardenNamespace"), bg.GardenNamespace, "must not be a project namespace"))
+		}
+	}
+
+	// the emergency credentials are checked by the authenticator built from this repository
+	allErrs = append(allErrs, validateFitsAuthenticatorImages(cfg, "break-glass credentials are enabled")...)
+
+	return allErrs
+}
+
+func validateFitsAuthenticatorImages(cfg *config.ControllerConfiguration, reason string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.Auth.AuthenticatorImage != imagevector.ImageNameFitsAuthnWebhook {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("auth", "authenticatorImage"), cfg.Auth.AuthenticatorImage, "must be "+imagevector.ImageNameFitsAuthnWebhook+" when "+reason))
+	}
+	for i, profile := range cfg.Profiles {
+		if profile.AuthenticatorImage != imagevector.ImageNameFitsAuthnWebhook {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("profiles").Index(i).Child("authenticatorImage"), profile.AuthenticatorImage, "must be "+imagevector.ImageNameFitsAuthnWebhook+" when "+reason))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,182 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateConfigurationAuthenticatorImage(t *testing.T) {
+	validConfig := func(image string) *config.ControllerConfiguration {
+		return &config.ControllerConfiguration{
+			Auth: config.Auth{
+				ProviderTenant:     "prvdr",
+				AuthenticatorImage: image,
+				Membership:         &config.MembershipBackend{Type: config.MembershipBackendNone},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		image      string
+		modify     func(*config.ControllerConfiguration)
+		wantFields []string
+	}{
+		{
+			name:  "authn-webhook without further features",
+			image: imagevector.ImageNameAuthnWebhook,
+		},
+		{
+			name:  "cache ttl with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.TokenWebhook = &config.TokenWebhook{
+					CacheTTL:     &metav1.Duration{Duration: time.Minute},
+					RetryBackoff: &config.RetryBackoff{Retries: ptr.To[int32](0)},
+				}
+			},
+		},
+		{
+			name:  "timeout with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.TokenWebhook = &config.TokenWebhook{Timeout: &metav1.Duration{Duration: time.Second}}
+			},
+			wantFields: []string{"auth.authenticatorImage"},
+		},
+		{
+			name:  "retries with the authn-webhook in a profile",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.TokenWebhook = &config.TokenWebhook{RetryBackoff: &config.RetryBackoff{Retries: ptr.To[int32](2)}}
+				c.Profiles = []config.AuthProfile{{Name: "other", Auth: validConfig(imagevector.ImageNameAuthnWebhook).Auth}}
+			},
+			wantFields: []string{"profiles[0].authenticatorImage"},
+		},
+		{
+			name:  "timeout and retries with the fits-authn-webhook",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.TokenWebhook = &config.TokenWebhook{
+					Timeout:      &metav1.Duration{Duration: time.Second},
+					RetryBackoff: &config.RetryBackoff{Retries: ptr.To[int32](2)},
+				}
+			},
+		},
+		{
+			name:  "readiness timeout with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.TokenWebhook = &config.TokenWebhook{ReadinessTimeout: &metav1.Duration{Duration: time.Minute}}
+			},
+			wantFields: []string{"auth.authenticatorImage"},
+		},
+		{
+			name:  "disabled readiness timeout with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.TokenWebhook = &config.TokenWebhook{ReadinessTimeout: &metav1.Duration{}}
+			},
+		},
+		{
+			name:  "static membership with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.Auth.Membership = &config.MembershipBackend{Type: config.MembershipBackendStatic, Static: &config.StaticMembership{}}
+			},
+			wantFields: []string{"auth.membership.type"},
+		},
+		{
+			name:  "metal v2 membership with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.Auth.Membership = &config.MembershipBackend{Type: config.MembershipBackendMetalV2, MetalV2: &config.MetalV2Membership{URL: "https://metal", Token: "token"}}
+			},
+			wantFields: []string{"auth.membership.type"},
+		},
+		{
+			name:  "static membership with the fits-authn-webhook",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.Auth.Membership = &config.MembershipBackend{Type: config.MembershipBackendStatic, Static: &config.StaticMembership{}}
+			},
+		},
+		{
+			name:  "profile named like the default profile",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.Profiles = []config.AuthProfile{{Name: config.DefaultProfileName, Auth: validConfig(imagevector.ImageNameAuthnWebhook).Auth}}
+			},
+			wantFields: []string{"profiles[0].name"},
+		},
+		{
+			name:  "break glass with the fits-authn-webhook",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.BreakGlass = &config.BreakGlass{GardenNamespace: "fits-break-glass"}
+			},
+		},
+		{
+			name:  "break glass without garden namespace",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.BreakGlass = &config.BreakGlass{}
+			},
+			wantFields: []string{"breakGlass.gardenNamespace"},
+		},
+		{
+			name:  "break glass in the garden namespace",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.BreakGlass = &config.BreakGlass{GardenNamespace: "garden"}
+			},
+			wantFields: []string{"breakGlass.gardenNamespace"},
+		},
+		{
+			name:  "break glass in a project namespace",
+			image: imagevector.ImageNameFitsAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.BreakGlass = &config.BreakGlass{GardenNamespace: "garden-project"}
+			},
+			wantFields: []string{"breakGlass.gardenNamespace"},
+		},
+		{
+			name:  "break glass with the authn-webhook",
+			image: imagevector.ImageNameAuthnWebhook,
+			modify: func(c *config.ControllerConfiguration) {
+				c.BreakGlass = &config.BreakGlass{GardenNamespace: "fits-break-glass"}
+			},
+			wantFields: []string{"auth.authenticatorImage"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(tt.image)
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+
+			errs := ValidateConfiguration(cfg)
+
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if len(got) != len(tt.wantFields) {
+				t.Fatalf("ValidateConfiguration() = %v, want errors for %v", errs, tt.wantFields)
+			}
+			for i := range got {
+				if got[i] != tt.wantFields[i] {
+					t.Errorf("error %d is for field %q, want %q", i, got[i], tt.wantFields[i])
+				}
+			}
+		})
+	}
+}
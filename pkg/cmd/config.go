@@ -6,6 +6,7 @@ import (
 
 	configapi "github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
 	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config/validation"
 	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 
 	"github.com/spf13/pflag"
@@ -27,7 +28,7 @@ func init() {
 	decoder = serializer.NewCodecFactory(scheme).UniversalDecoder()
 }
 
-// RegistryOptions holds options related to the registry service.
+// AuthOptions holds options related to the fits-authn controller configuration.
 type AuthOptions struct {
 	ConfigLocation string
 	config         *AuthServiceConfig
@@ -35,7 +36,7 @@ type AuthOptions struct {
 
 // AddFlags implements Flagger.AddFlags.
 func (o *AuthOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&o.ConfigLocation, "config", "", "Path to registry service configuration")
+	fs.StringVar(&o.ConfigLocation, "config", "", "Path to fits-authn controller configuration")
 }
 
 // Complete implements Completer.Complete.
@@ -54,9 +55,9 @@ func (o *AuthOptions) Complete() error {
 		return err
 	}
 
-	// if errs := validation.ValidateConfiguration(&config); len(errs) > 0 {
-	// 	return errs.ToAggregate()
-	// }
+	if errs := validation.ValidateConfiguration(&config); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
 
 	o.config = &AuthServiceConfig{
 		config: config,
@@ -65,17 +66,17 @@ func (o *AuthOptions) Complete() error {
 	return nil
 }
 
-// Completed returns the decoded RegistryServiceConfiguration instance. Only call this if `Complete` was successful.
+// Completed returns the decoded AuthServiceConfig instance. Only call this if `Complete` was successful.
 func (o *AuthOptions) Completed() *AuthServiceConfig {
 	return o.config
 }
 
-// RegistryServiceConfig contains configuration information about the registry service.
+// AuthServiceConfig contains the configuration of the fits-authn controller.
 type AuthServiceConfig struct {
 	config configapi.ControllerConfiguration
 }
 
-// Apply applies the RegistryOptions to the passed ControllerOptions instance.
+// Apply applies the AuthOptions to the passed ControllerConfiguration instance.
 func (c *AuthServiceConfig) Apply(config *configapi.ControllerConfiguration) {
 	*config = c.config
 }
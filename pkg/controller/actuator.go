@@ -3,18 +3,34 @@ package controller
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
 	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/validation"
 	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	configv1alpha1 "github.com/fi-ts/gardener-extension-authn/pkg/apis/config/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/grouprolebinding"
 	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+	"github.com/fi-ts/gardener-extension-authn/pkg/subjectaccessreview"
+	"github.com/fi-ts/gardener-extension-authn/pkg/version"
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/controllerutils"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/gardener/gardener/pkg/extensions"
+	"github.com/gardener/gardener/pkg/utils/flow"
 	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
 	"github.com/gardener/gardener/pkg/utils/managedresources"
 	"github.com/go-logr/logr"
 	"github.com/metal-stack/metal-lib/pkg/pointer"
@@ -22,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -29,27 +46,74 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// deletionRequeueInterval is the interval in which the deletion of the managed resources is checked.
+	deletionRequeueInterval = 5 * time.Second
+	// healthRequeueInterval is the interval in which the health of the managed resources is checked.
+	healthRequeueInterval = 10 * time.Second
+)
+
+const (
+	eventReasonProviderConfigInvalid    = "ProviderConfigInvalid"
+	eventReasonTenantMissing            = "TenantMissing"
+	eventReasonAuthenticationConflict   = "AuthenticationConflict"
+	eventReasonManagedResourceCreated   = "ManagedResourceCreated"
+	eventReasonManagedResourceFailed    = "ManagedResourceFailed"
+	eventReasonManagedResourceUnhealthy = "ManagedResourceUnhealthy"
+	eventReasonManagedResourcesDeleting = "ManagedResourcesDeleting"
+	eventReasonManagedResourcesDeleted  = "ManagedResourcesDeleted"
+)
+
 // NewActuator returns an actuator responsible for Extension resources.
-func NewActuator(mgr manager.Manager, config config.ControllerConfiguration) extension.Actuator {
+// The garden reader is used to look up the projects of the shoots, the garden client to publish the
+// break-glass credentials. Both may be nil.
+func NewActuator(mgr manager.Manager, gardenReader client.Reader, gardenClient client.Client, config config.ControllerConfiguration) extension.Actuator {
 	return &actuator{
-		client:  mgr.GetClient(),
-		decoder: serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
-		config:  config,
+		client:       mgr.GetClient(),
+		decoder:      serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		recorder:     mgr.GetEventRecorderFor(ControllerName),
+		config:       config,
+		gardenReader: gardenReader,
+		gardenClient: gardenClient,
+		tenants:      newTenantResolver(gardenReader, config.Auth.ProjectTenant),
 	}
 }
 
 type actuator struct {
-	client  client.Client
-	decoder runtime.Decoder
-	config  config.ControllerConfiguration
+	client       client.Client
+	decoder      runtime.Decoder
+	recorder     record.EventRecorder
+	config       config.ControllerConfiguration
+	gardenReader client.Reader
+	gardenClient client.Client
+	tenants      *tenantResolver
 }
 
-// ForceDelete implements extension.Actuator.
-func (a *actuator) ForceDelete(context.Context, logr.Logger, *extensionsv1alpha1.Extension) error {
-	return nil
+// ForceDelete removes the finalizers of the managed resources and deletes them without waiting for the
+// gardener-resource-manager, the objects they contain are left behind. It is used for shoots that cannot be
+// deleted gracefully, therefore the Cluster resource is optional.
+func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
+	namespace := ex.GetNamespace()
+
+	cluster, err := controller.GetCluster(ctx, a.client, namespace)
+	if err != nil {
+		log.Error(err, "unable to get cluster, resources in the garden cluster are not cleaned up")
+		cluster = nil
+	}
+
+	for _, name := range []string{v1alpha1.ShootAuthResourceName, v1alpha1.SeedAuthResourceName} {
+		if err := a.forceDeleteManagedResource(ctx, namespace, name); err != nil {
+			return err
+		}
+	}
+
+	log.Info("managed resources force deleted successfully")
+
+	return a.cleanup(ctx, log, namespace, cluster)
 }
 
 // Reconcile the Extension resource.
@@ -61,23 +125,174 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, ex *extension
 		return err
 	}
 
-	authnConfig := &v1alpha1.AuthnConfig{}
+	authnConfig := &authn.AuthnConfig{}
 	if ex.Spec.ProviderConfig != nil {
 		if _, _, err := a.decoder.Decode(ex.Spec.ProviderConfig.Raw, nil, authnConfig); err != nil {
+			a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonProviderConfigInvalid, "Failed to decode provider config: %v", err)
 			return fmt.Errorf("failed to decode provider config: %w", err)
 		}
 	}
 
-	if err := a.createResources(ctx, log, authnConfig, cluster, namespace); err != nil {
+	tenant, err := a.tenants.tenant(ctx, cluster.Shoot)
+	if err != nil {
+		a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonTenantMissing, "Unable to determine tenant of the shoot: %v", err)
+		return err
+	}
+
+	profile, auth, err := selectProfile(&a.config, authnConfig, tenant)
+	if err != nil {
 		return err
 	}
+	applyProfileDefaults(authnConfig, auth)
+
+	if errs := validation.ValidateAuthnConfig(authnConfig, tenant, cluster.Shoot.Annotations); len(errs) > 0 {
+		return fmt.Errorf("invalid provider config: %w", errs.ToAggregate())
+	}
+	if errs := validation.ValidateAuthenticatorSupport(authnConfig, auth.AuthenticatorImage); len(errs) > 0 {
+		a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonProviderConfigInvalid, "Provider config is not supported by the authenticator: %v", errs.ToAggregate())
+		return fmt.Errorf("provider config is not supported by the authenticator of profile %s: %w", profile, errs.ToAggregate())
+	}
+
+	native, err := nativeAuthentication(ctx, a.client, cluster, namespace)
+	if err != nil {
+		return fmt.Errorf("unable to determine native authentication of the shoot: %w", err)
+	}
+	if err := ValidateShootAuthentication(authnConfig, &a.config, native); err != nil {
+		a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonAuthenticationConflict, "Shoot authentication conflicts with the token webhook: %v", err)
+		return err
+	}
+
+	var bg *breakGlass
+	if a.config.BreakGlass != nil {
+		bg, err = a.reconcileBreakGlass(ctx, log, ex, cluster)
+		if err != nil {
+			return err
+		}
+	} else if err := a.deleteBreakGlass(ctx, log, namespace, nil); err != nil {
+		return err
+	}
+
+	var project *gardencorev1beta1.Project
+	if a.config.ProjectMembers != nil {
+		if a.gardenReader == nil {
+			return fmt.Errorf("project members cannot be synchronized without access to the garden cluster")
+		}
+
+		project, err = gutil.ProjectForNamespaceFromReader(ctx, a.gardenReader, cluster.Shoot.Namespace)
+		if err != nil {
+			return fmt.Errorf("unable to read project of namespace %s: %w", cluster.Shoot.Namespace, err)
+		}
+	}
+
+	inputHash, err := a.inputHash(authnConfig, auth, native, cluster, tenant, profile, bg, project)
+	if err != nil {
+		return err
+	}
+
+	if a.unchanged(ctx, log, ex, cluster, inputHash) {
+		log.Info("inputs are unchanged and managed resources are healthy, skipping update of the managed resources")
+		reconcilesSkipped.Inc()
+	} else {
+		configHash, err := a.createResources(ctx, log, ex, authnConfig, auth, cluster, tenant, bg, project)
+		if err != nil {
+			return err
+		}
+
+		status, err := a.authnStatus(authnConfig, auth, native, tenant, profile, namespace, configHash, inputHash)
+		if err != nil {
+			return err
+		}
+		if err := a.updateProviderStatus(ctx, ex, status); err != nil {
+			return err
+		}
+	}
+
+	// the group rolebindings depend on the namespaces of the shoot, so they are synced on every reconciliation
+	if groupRoleBindingController(&a.config).Mode == config.GroupRoleBindingControllerModeExtension && !controller.IsHibernated(cluster) {
+		if err := a.syncGroupRoleBindings(ctx, log, authnConfig, cluster, namespace, shootAccessSecretName()); err != nil {
+			return err
+		}
+	}
+
+	// the kubeconfig contains the cluster ca from the garden, which is not part of the input hash, it is published
+	// last because it requeues the reconciliation until the shoot is available
+	if a.config.OIDCKubeconfig != nil {
+		if err := a.publishOIDCKubeconfig(ctx, log, authnConfig, cluster); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
 // Delete the Extension resource.
 func (a *actuator) Delete(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
-	return a.deleteResources(ctx, log, ex.GetNamespace())
+	cluster, err := controller.GetCluster(ctx, a.client, ex.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	if err := a.deleteResources(ctx, log, ex); err != nil {
+		return err
+	}
+
+	return a.cleanup(ctx, log, ex.GetNamespace(), cluster)
+}
+
+// cleanup deletes the secrets and config maps the extension created besides the managed resources. It must only
+// be called once the managed resources are gone, because their objects refer to the shoot access secret.
+func (a *actuator) cleanup(ctx context.Context, log logr.Logger, namespace string, cluster *controller.Cluster) error {
+	if err := kubernetesutils.DeleteObject(ctx, a.client, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      shootAccessSecretName(),
+			Namespace: namespace,
+		},
+	}); err != nil {
+		return fmt.Errorf("unable to delete shoot access secret: %w", err)
+	}
+
+	if cluster != nil {
+		if err := a.deleteOIDCKubeconfig(ctx, cluster); err != nil {
+			return err
+		}
+		if cluster.Shoot != nil {
+			a.tenants.evict(cluster.Shoot.Namespace)
+		}
+	}
+
+	return a.deleteBreakGlass(ctx, log, namespace, cluster)
+}
+
+// forceDeleteManagedResource deletes the given managed resource and removes the finalizers of it and its secrets.
+func (a *actuator) forceDeleteManagedResource(ctx context.Context, namespace, name string) error {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := a.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, mr); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if err := managedresources.Delete(ctx, a.client, namespace, name, false); err != nil {
+		return err
+	}
+
+	for _, ref := range mr.Spec.SecretRefs {
+		secret := &corev1.Secret{}
+		if err := a.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if err := controllerutils.RemoveAllFinalizers(ctx, a.client, secret); err != nil {
+			return fmt.Errorf("unable to remove finalizers of secret %s: %w", ref.Name, err)
+		}
+	}
+
+	if err := controllerutils.RemoveAllFinalizers(ctx, a.client, mr); err != nil {
+		return fmt.Errorf("unable to remove finalizers of managed resource %s: %w", name, err)
+	}
+
+	return nil
 }
 
 // Restore the Extension resource.
@@ -90,89 +305,287 @@ func (a *actuator) Migrate(ctx context.Context, log logr.Logger, ex *extensionsv
 	return nil
 }
 
-func (a *actuator) createResources(ctx context.Context, log logr.Logger, authConfig *v1alpha1.AuthnConfig, cluster *controller.Cluster, namespace string) error {
-	shootAccessSecret := gutil.NewShootAccessSecret(gutil.SecretNamePrefixShootAccess+"group-rolebinding-controller", namespace)
+func (a *actuator) createResources(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension, authConfig *authn.AuthnConfig, auth *config.Auth, cluster *controller.Cluster, tenant string, bg *breakGlass, project *gardencorev1beta1.Project) (string, error) {
+	namespace := ex.GetNamespace()
+
+	start := time.Now()
+	shootAccessSecret := gutil.NewShootAccessSecret(shootAccessSecretName(), namespace)
 	if err := shootAccessSecret.Reconcile(ctx, a.client); err != nil {
-		return err
+		return "", err
 	}
+	observePhase(phaseShootAccessSecret, start)
 
 	shootObjects := shootObjects()
 
-	seedObjects, err := seedObjects(&a.config, authConfig, cluster, namespace, shootAccessSecret.Secret.Name)
+	infoObjects, err := infoShootObjects(&a.config, auth, authConfig, tenant)
 	if err != nil {
-		return err
+		return "", err
+	}
+	shootObjects = append(shootObjects, infoObjects...)
+
+	if bg != nil {
+		shootObjects = append(shootObjects, breakGlassShootObjects()...)
+	}
+
+	if project != nil {
+		shootObjects = append(shootObjects, projectMemberObjects(project, a.config.ProjectMembers.RoleMapping)...)
+	}
+
+	seedObjects, err := seedObjects(&a.config, auth, authConfig, cluster, tenant, namespace, shootAccessSecret.Secret.Name, bg)
+	if err != nil {
+		return "", err
 	}
 
 	shootResources, err := managedresources.NewRegistry(kubernetes.ShootScheme, kubernetes.ShootCodec, kubernetes.ShootSerializer).AddAllAndSerialize(shootObjects...)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	seedResources, err := managedresources.NewRegistry(kubernetes.SeedScheme, kubernetes.SeedCodec, kubernetes.SeedSerializer).AddAllAndSerialize(seedObjects...)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	start = time.Now()
 	if err := managedresources.CreateForShoot(ctx, a.client, namespace, v1alpha1.ShootAuthResourceName, "fits-authn", false, shootResources); err != nil {
-		return err
+		a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonManagedResourceFailed, "Failed to create managed resource %s: %v", v1alpha1.ShootAuthResourceName, err)
+		return "", err
 	}
 
 	log.Info("managed resource created successfully", "name", v1alpha1.ShootAuthResourceName)
+	a.recorder.Eventf(ex, corev1.EventTypeNormal, eventReasonManagedResourceCreated, "Created managed resource %s", v1alpha1.ShootAuthResourceName)
 
 	if err := managedresources.CreateForSeed(ctx, a.client, namespace, v1alpha1.SeedAuthResourceName, false, seedResources); err != nil {
-		return err
+		a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonManagedResourceFailed, "Failed to create managed resource %s: %v", v1alpha1.SeedAuthResourceName, err)
+		return "", err
 	}
 
 	log.Info("managed resource created successfully", "name", v1alpha1.SeedAuthResourceName)
+	a.recorder.Eventf(ex, corev1.EventTypeNormal, eventReasonManagedResourceCreated, "Created managed resource %s", v1alpha1.SeedAuthResourceName)
+
+	observePhase(phaseManagedResourceCreate, start)
+
+	if err := a.checkManagedResourcesHealthy(ctx, log, ex, cluster); err != nil {
+		return "", err
+	}
+
+	return configHash(shootResources, seedResources), nil
+}
+
+// checkManagedResourcesHealthy checks once whether the managed resources are applied and all of their objects are
+// healthy, such that the kube-apiserver is not pointed to a token webhook that does not work. Unhealthy managed
+// resources are checked again later instead of blocking a worker. The shoot managed resource is not checked for
+// hibernated shoots, because it cannot be applied without a kube-apiserver.
+func (a *actuator) checkManagedResourcesHealthy(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension, cluster *controller.Cluster) error {
+	names := []string{v1alpha1.SeedAuthResourceName}
+	if !controller.IsHibernated(cluster) {
+		names = append(names, v1alpha1.ShootAuthResourceName)
+	}
+
+	var (
+		unhealthy []string
+		since     time.Time
+	)
+	for _, name := range names {
+		mr := &resourcesv1alpha1.ManagedResource{}
+		if err := a.client.Get(ctx, client.ObjectKey{Namespace: ex.GetNamespace(), Name: name}, mr); err != nil {
+			return err
+		}
+
+		// in contrast to managedresources.WaitUntilHealthy the error keeps the conditions of the managed resource,
+		// which name the objects that could not be applied or are unhealthy
+		if err := health.CheckManagedResource(mr); err != nil {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: %v", name, err))
+			if s := unhealthySince(mr); since.IsZero() || s.Before(since) {
+				since = s
+			}
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		timeout := a.config.ManagedResources.HealthTimeout.Duration
+		if time.Since(since) > timeout {
+			a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonManagedResourceUnhealthy, "Managed resources did not become healthy within %s: %s", timeout, strings.Join(unhealthy, "; "))
+			return fmt.Errorf("managed resources did not become healthy within %s: %s", timeout, strings.Join(unhealthy, "; "))
+		}
+
+		a.recorder.Eventf(ex, corev1.EventTypeNormal, eventReasonManagedResourceUnhealthy, "Waiting for the managed resources to become healthy: %s", strings.Join(unhealthy, "; "))
+		return &reconcilerutils.RequeueAfterError{
+			Cause:        fmt.Errorf("waiting for managed resources to become healthy: %s", strings.Join(unhealthy, "; ")),
+			RequeueAfter: healthRequeueInterval,
+		}
+	}
+
+	log.Info("managed resources are healthy")
 
 	return nil
 }
 
-func (a *actuator) deleteResources(ctx context.Context, log logr.Logger, namespace string) error {
-	log.Info("deleting managed resource for registry cache")
+// unhealthySince returns the time since which the given managed resource is unhealthy, which is the latest
+// transition of its conditions that are not in the healthy state. Managed resources without such a condition, e.g.
+// because the gardener-resource-manager did not observe their latest generation yet, are unhealthy since now.
+func unhealthySince(mr *resourcesv1alpha1.ManagedResource) time.Time {
+	healthy := map[gardencorev1beta1.ConditionType]gardencorev1beta1.ConditionStatus{
+		resourcesv1alpha1.ResourcesApplied:     gardencorev1beta1.ConditionTrue,
+		resourcesv1alpha1.ResourcesHealthy:     gardencorev1beta1.ConditionTrue,
+		resourcesv1alpha1.ResourcesProgressing: gardencorev1beta1.ConditionFalse,
+	}
 
-	if err := managedresources.Delete(ctx, a.client, namespace, v1alpha1.ShootAuthResourceName, false); err != nil {
-		return err
+	var since time.Time
+	for _, c := range mr.Status.Conditions {
+		if status, ok := healthy[c.Type]; ok && c.Status != status && c.LastTransitionTime.After(since) {
+			since = c.LastTransitionTime.Time
+		}
+	}
+	if since.IsZero() {
+		return time.Now()
 	}
+	return since
+}
 
-	if err := managedresources.Delete(ctx, a.client, namespace, v1alpha1.SeedAuthResourceName, false); err != nil {
-		return err
+func (a *actuator) syncGroupRoleBindings(ctx context.Context, log logr.Logger, authConfig *authn.AuthnConfig, cluster *controller.Cluster, namespace, shootAccessSecretName string) error {
+	shootClient, err := newShootClient(ctx, a.client, namespace, extensions.GenericTokenKubeconfigSecretNameFromCluster(cluster), shootAccessSecretName)
+	if err != nil {
+		return &reconcilerutils.RequeueAfterError{
+			Cause:        err,
+			RequeueAfter: 30 * time.Second,
+		}
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
-	defer cancel()
+	grcConfig := groupRoleBindingController(&a.config)
+	if err := grouprolebinding.Sync(ctx, shootClient, grouprolebinding.Options{
+		ClusterName:       cluster.Shoot.Name,
+		ExcludeNamespaces: grcConfig.ExcludeNamespaces,
+		ExpectedGroups:    grcConfig.ExpectedGroups,
+		AdditionalTenants: additionalTenantRoles(authConfig),
+	}); err != nil {
+		return fmt.Errorf("unable to sync group rolebindings: %w", err)
+	}
 
-	if err := managedresources.WaitUntilDeleted(timeoutCtx, a.client, namespace, v1alpha1.ShootAuthResourceName); err != nil {
-		return err
+	log.Info("group rolebindings synced successfully")
+
+	return nil
+}
+
+func additionalTenantRoles(authConfig *authn.AuthnConfig) []grouprolebinding.TenantRoles {
+	var tenants []grouprolebinding.TenantRoles
+	for _, t := range authConfig.AdditionalTenants {
+		tenants = append(tenants, grouprolebinding.TenantRoles{
+			Name:        strings.ToLower(t.Name),
+			RoleMapping: t.RoleMapping,
+		})
 	}
+	return tenants
+}
+
+// deleteResources deletes the managed resources of the extension. It does not wait for the deletion to finish,
+// instead the reconciliation is requeued as long as the managed resources still exist. An error is reported once
+// the deletion takes longer than the configured timeout.
+func (a *actuator) deleteResources(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension) error {
+	namespace := ex.GetNamespace()
+	names := []string{v1alpha1.ShootAuthResourceName, v1alpha1.SeedAuthResourceName}
+
+	log.Info("deleting managed resources of the authn components")
 
-	if err := managedresources.WaitUntilDeleted(timeoutCtx, a.client, namespace, v1alpha1.SeedAuthResourceName); err != nil {
+	var fns []flow.TaskFn
+	for _, name := range names {
+		fns = append(fns, func(ctx context.Context) error {
+			return managedresources.Delete(ctx, a.client, namespace, name, false)
+		})
+	}
+	if err := flow.Parallel(fns...)(ctx); err != nil {
 		return err
 	}
 
+	var remaining []string
+	for _, name := range names {
+		if err := a.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &resourcesv1alpha1.ManagedResource{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		remaining = append(remaining, name)
+	}
+
+	start := time.Now()
+	if ex.DeletionTimestamp != nil {
+		start = ex.DeletionTimestamp.Time
+	}
+
+	if len(remaining) > 0 {
+		timeout := a.config.ManagedResources.DeletionTimeout.Duration
+		if time.Since(start) > timeout {
+			a.recorder.Eventf(ex, corev1.EventTypeWarning, eventReasonManagedResourceFailed, "Managed resources %s were not deleted within %s", strings.Join(remaining, ", "), timeout)
+			return fmt.Errorf("managed resources %s were not deleted within %s", strings.Join(remaining, ", "), timeout)
+		}
+
+		a.recorder.Eventf(ex, corev1.EventTypeNormal, eventReasonManagedResourcesDeleting, "Waiting for the managed resources %s to be deleted", strings.Join(remaining, ", "))
+		return &reconcilerutils.RequeueAfterError{
+			Cause:        fmt.Errorf("waiting for managed resources %s to be deleted", strings.Join(remaining, ", ")),
+			RequeueAfter: deletionRequeueInterval,
+		}
+	}
+
+	observePhase(phaseDeleteWait, start)
+
+	log.Info("managed resources deleted successfully")
+	a.recorder.Event(ex, corev1.EventTypeNormal, eventReasonManagedResourcesDeleted, "Deleted the managed resources")
+
 	return nil
 }
 
-func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnConfig, cluster *controller.Cluster, namespace, shootAccessSecretName string) ([]client.Object, error) {
-	authnImage, err := imagevector.ImageVector().FindImage("authn-webhook")
+func seedObjects(cc *config.ControllerConfiguration, auth *config.Auth, authConfig *authn.AuthnConfig, cluster *controller.Cluster, tenant, namespace, shootAccessSecretName string, bg *breakGlass) ([]client.Object, error) {
+	authnImage, grcImage, err := componentImages(auth)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find authn-webhook image: %w", err)
+		return nil, err
 	}
-	grcImage, err := imagevector.ImageVector().FindImage("group-rolebinding-controller")
-	if err != nil {
-		return nil, fmt.Errorf("failed to find group-rolebinding-controller image: %w", err)
+
+	// the images built from this repository contain several binaries, so the command needs to be specified
+	var authnCommand []string
+	if auth.AuthenticatorImage == imagevector.ImageNameFitsAuthnWebhook {
+		authnCommand = []string{"/authn-webhook"}
+	}
+
+	// the health endpoint is only served by the authenticator built from this repository
+	readinessHandler := corev1.ProbeHandler{
+		TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8443)},
+	}
+	if auth.AuthenticatorImage == imagevector.ImageNameFitsAuthnWebhook {
+		readinessHandler = corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8443), Scheme: corev1.URISchemeHTTP},
+		}
+	}
+
+	accessScope := authConfig.AccessScope
+	if accessScope == "" {
+		accessScope = authn.AccessScopeTenant
 	}
 
-	tenant, ok := cluster.Shoot.Annotations[tag.ClusterTenant]
-	if !ok {
-		return nil, fmt.Errorf("cluster has no tenant annotation")
+	var (
+		additionalTenants []string
+		grcTenantArgs     []string
+	)
+	for _, t := range additionalTenantRoles(authConfig) {
+		additionalTenants = append(additionalTenants, t.Name)
+		grcTenantArgs = append(grcTenantArgs, fmt.Sprintf("--additionalTenant=%s", t.String()))
 	}
 
+	tokenWebhook := TokenWebhookSettingsFor(cc.TokenWebhook, authConfig.TokenWebhook)
+	grcConfig := groupRoleBindingController(cc)
+
 	replicas := int32(1)
 	if controller.IsHibernated(cluster) {
 		replicas = 0
 	}
 
+	// a suspended webhook is not queried by the kube-apiserver unless it accepts the break-glass credentials,
+	// the rolebindings are still managed
+	webhookReplicas := replicas
+	if !TokenWebhookConfigured(authConfig, cc) {
+		webhookReplicas = 0
+	}
+
 	webhookDeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "kube-jwt-authn-webhook",
@@ -182,7 +595,7 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: pointer.Pointer(replicas),
+			Replicas: pointer.Pointer(webhookReplicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					"k8s-app": "kube-jwt-authn-webhook",
@@ -222,8 +635,14 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 					Containers: []corev1.Container{
 						{
 							Name:            "kubernetes-authn-webhook",
-							Image:           authnImage.String(),
+							Image:           authnImage,
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         authnCommand,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler:     readinessHandler,
+								PeriodSeconds:    5,
+								FailureThreshold: 3,
+							},
 							Ports: []corev1.ContainerPort{
 								{
 									ContainerPort: 8443,
@@ -250,7 +669,7 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 								},
 								{
 									Name:  "GROUPSPREFIXTOREMOVE",
-									Value: "k8s",
+									Value: auth.GroupsPrefix,
 								},
 								{
 									Name:  "TENANT",
@@ -258,44 +677,39 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 								},
 								{
 									Name:  "PROVIDERTENANT",
-									Value: cc.Auth.ProviderTenant,
+									Value: auth.ProviderTenant,
+								},
+								{
+									Name:  "ADDITIONAL_TENANTS",
+									Value: strings.Join(additionalTenants, ","),
 								},
 								{
 									Name:  "CLUSTER",
 									Value: cluster.Shoot.Name,
 								},
 								{
-									Name: "METAL_URL",
-									ValueFrom: &corev1.EnvVarSource{
-										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{
-												Name: "kube-jwt-authn-webhook-metalapi-secret",
-											},
-											Key: "metalapi-url",
-										},
-									},
+									Name:  "PROJECT",
+									Value: cluster.Shoot.Annotations[tag.ClusterProject],
+								},
+								{
+									Name:  "ACCESS_SCOPE",
+									Value: string(accessScope),
 								},
 								{
-									Name: "METAL_HMAC",
-									ValueFrom: &corev1.EnvVarSource{
-										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{
-												Name: "kube-jwt-authn-webhook-metalapi-secret",
-											},
-											Key: "metalapi-hmac",
-										},
-									},
+									Name:  "TIMEOUT",
+									Value: tokenWebhook.Timeout.String(),
 								},
 								{
-									Name: "METAL_HMACAUTHTYPE",
-									ValueFrom: &corev1.EnvVarSource{
-										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{
-												Name: "kube-jwt-authn-webhook-metalapi-secret",
-											},
-											Key: "metalapi-authtype",
-										},
-									},
+									Name:  "RETRIES",
+									Value: strconv.Itoa(int(tokenWebhook.Retries)),
+								},
+								{
+									Name:  "RETRY_INITIAL_DELAY",
+									Value: tokenWebhook.RetryInitialDelay.String(),
+								},
+								{
+									Name:  "SUSPENDED",
+									Value: strconv.FormatBool(authConfig.Suspended),
 								},
 							},
 						},
@@ -335,15 +749,22 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 					Containers: []corev1.Container{
 						{
 							Name:            "group-rolebinding-controller",
-							Image:           grcImage.String(),
+							Image:           grcImage,
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							Command:         []string{"/group-rolebinding-controller"},
-							Args: []string{
-								"--excludeNamespaces=kube-system,kube-public,kube-node-lease,default",
-								"--expectedGroupsList=admin,edit,view",
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          metricsPortName,
+									ContainerPort: metricsPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Args: append([]string{
+								fmt.Sprintf("--excludeNamespaces=%s", strings.Join(grcConfig.ExcludeNamespaces, ",")),
+								fmt.Sprintf("--expectedGroupsList=%s", strings.Join(grcConfig.ExpectedGroups, ",")),
 								fmt.Sprintf("--clustername=%s", cluster.Shoot.Name),
 								fmt.Sprintf("--kubeconfig=%s", gutil.PathGenericKubeconfig),
-							},
+							}, grcTenantArgs...),
 						},
 					},
 				},
@@ -355,20 +776,65 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 		return nil, err
 	}
 
-	objects := []client.Object{
-		&corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "kube-jwt-authn-webhook-metalapi-secret",
-				Namespace: namespace,
+	membership, err := membershipObjects(auth.Membership, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookContainer := &webhookDeployment.Spec.Template.Spec.Containers[0]
+	webhookContainer.Env = append(webhookContainer.Env, membership.env...)
+
+	if cc.Authorization != nil && cc.Authorization.Enabled {
+		var rules []subjectaccessreview.Rule
+		for _, r := range cc.Authorization.ProviderTenantDeny {
+			rules = append(rules, subjectaccessreview.Rule{
+				Verbs:     r.Verbs,
+				Resources: r.Resources,
+			})
+		}
+
+		deny, err := json.Marshal(rules)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode authorization rules: %w", err)
+		}
+
+		webhookContainer.Env = append(webhookContainer.Env, corev1.EnvVar{
+			Name:  "AUTHZ_PROVIDER_TENANT_DENY",
+			Value: string(deny),
+		})
+	}
+	webhookContainer.VolumeMounts = append(webhookContainer.VolumeMounts, membership.volumeMounts...)
+	webhookDeployment.Spec.Template.Spec.Volumes = append(webhookDeployment.Spec.Template.Spec.Volumes, membership.volumes...)
+
+	webhookService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-jwt-authn-webhook",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": "kube-jwt-authn-webhook",
 			},
-			StringData: map[string]string{
-				"metalapi-url":      cc.Auth.MetalURL,
-				"metalapi-hmac":     cc.Auth.MetalHMAC,
-				"metalapi-authtype": cc.Auth.MetalAuthType,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app": "kube-jwt-authn-webhook",
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "webhook",
+					Port:       8443,
+					TargetPort: intstr.FromInt(8443),
+				},
+				{
+					Name:       metricsPortName,
+					Port:       metricsPort,
+					TargetPort: intstr.FromInt(metricsPort),
+				},
 			},
 		},
+	}
+
+	objects := []client.Object{
 		webhookDeployment,
-		grcDeployment,
 		&policyv1.PodDisruptionBudget{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      webhookDeployment.Name,
@@ -386,27 +852,25 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 				},
 			},
 		},
-		&corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "kube-jwt-authn-webhook",
-				Namespace: namespace,
-				Labels: map[string]string{
-					"app": "kube-jwt-authn-webhook",
-				},
-			},
-			Spec: corev1.ServiceSpec{
-				Selector: map[string]string{
-					"app": "kube-jwt-authn-webhook",
-				},
-				Ports: []corev1.ServicePort{
-					{
-						Port:       8443,
-						TargetPort: intstr.FromInt(8443),
-					},
-				},
-			},
-		},
+		webhookService,
+	}
+
+	objects = append(objects, membership.objects...)
+
+	if bg != nil {
+		objects = append(objects, breakGlassSeedObjects(bg, webhookDeployment)...)
+	}
+
+	runGRCDeployment := grcConfig.Mode == config.GroupRoleBindingControllerModeDeployment
+	if runGRCDeployment {
+		objects = append(objects, grcDeployment)
+	}
+
+	monitoring, err := monitoringSeedObjects(namespace, webhookService, auth.AuthenticatorImage == imagevector.ImageNameFitsAuthnWebhook, runGRCDeployment, webhookReplicas > 0)
+	if err != nil {
+		return nil, err
 	}
+	objects = append(objects, monitoring...)
 
 	if cc.ImagePullSecret != nil && cc.ImagePullSecret.DockerConfigJSON != "" {
 		content, err := base64.StdEncoding.DecodeString(cc.ImagePullSecret.DockerConfigJSON)
@@ -426,31 +890,59 @@ func seedObjects(cc *config.ControllerConfiguration, authConfig *v1alpha1.AuthnC
 			Data: map[string][]byte{
 				".dockerconfigjson": content,
 			},
-		}, &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "group-rolebinding-controller-registry-credentials",
-				Namespace: namespace,
-				Labels: map[string]string{
-					"app": "group-rolebinding-controller-registry-credentials",
-				},
-			},
-			Type: corev1.SecretTypeDockerConfigJson,
-			Data: map[string][]byte{
-				".dockerconfigjson": content,
-			},
 		})
 
 		webhookDeployment.Spec.Template.Spec.ImagePullSecrets = append(webhookDeployment.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{
 			Name: "kube-jwt-authn-webhook-registry-credentials",
 		})
-		grcDeployment.Spec.Template.Spec.ImagePullSecrets = append(grcDeployment.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{
-			Name: "group-rolebinding-controller-registry-credentials",
-		})
+
+		if runGRCDeployment {
+			objects = append(objects, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "group-rolebinding-controller-registry-credentials",
+					Namespace: namespace,
+					Labels: map[string]string{
+						"app": "group-rolebinding-controller-registry-credentials",
+					},
+				},
+				Type: corev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{
+					".dockerconfigjson": content,
+				},
+			})
+
+			grcDeployment.Spec.Template.Spec.ImagePullSecrets = append(grcDeployment.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{
+				Name: "group-rolebinding-controller-registry-credentials",
+			})
+		}
 	}
 
 	return objects, nil
 }
 
+// shootAccessSecretName returns the name of the secret that contains the token of the group rolebinding controller
+// for the shoot.
+func shootAccessSecretName() string {
+	return gutil.SecretNamePrefixShootAccess + "group-rolebinding-controller"
+}
+
+// componentImages returns the images of the token webhook and the group rolebinding controller.
+func componentImages(auth *config.Auth) (authnImage, grcImage string, err error) {
+	webhook, err := imagevector.ImageVector().FindImage(auth.AuthenticatorImage)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find %s image: %w", auth.AuthenticatorImage, err)
+	}
+	webhook.WithOptionalTag(version.Version)
+
+	grc, err := imagevector.ImageVector().FindImage(imagevector.ImageNameGroupRoleBindingController)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find group-rolebinding-controller image: %w", err)
+	}
+	grc.WithOptionalTag(version.Version)
+
+	return webhook.String(), grc.String(), nil
+}
+
 func shootObjects() []client.Object {
 	return []client.Object{
 		&rbacv1.ClusterRoleBinding{
@@ -471,3 +963,19 @@ func shootObjects() []client.Object {
 		},
 	}
 }
+
+// groupRoleBindingController returns the configuration of the group rolebinding controller. It is set by the
+// defaulting of the controller configuration, without it the defaults of the configuration api are used.
+func groupRoleBindingController(cc *config.ControllerConfiguration) *config.GroupRoleBindingController {
+	if cc.GroupRoleBindingController != nil {
+		return cc.GroupRoleBindingController
+	}
+
+	defaults := &configv1alpha1.GroupRoleBindingController{}
+	configv1alpha1.SetDefaults_GroupRoleBindingController(defaults)
+
+	grc := &config.GroupRoleBindingController{}
+	// the conversion of the generated functions does not fail
+	_ = configv1alpha1.Convert_v1alpha1_GroupRoleBindingController_To_config_GroupRoleBindingController(defaults, grc, nil)
+	return grc
+}
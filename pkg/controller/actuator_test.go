@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// testFinalizer keeps the objects of the fake client from being deleted.
+const testFinalizer = "resources.gardener.cloud/gardener-resource-manager"
+
+// managedResource returns a managed resource of the test namespace whose conditions transitioned at the given time.
+func managedResource(name string, healthy bool, transition time.Time) *resourcesv1alpha1.ManagedResource {
+	status, message := gardencorev1beta1.ConditionTrue, "All resources are healthy."
+	if !healthy {
+		status, message = gardencorev1beta1.ConditionFalse, `Deployment "kube-jwt-authn-webhook" is unhealthy`
+	}
+
+	return &resourcesv1alpha1.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testSeedNamespace},
+		Status: resourcesv1alpha1.ManagedResourceStatus{
+			Conditions: []gardencorev1beta1.Condition{
+				{Type: resourcesv1alpha1.ResourcesApplied, Status: gardencorev1beta1.ConditionTrue, LastTransitionTime: metav1.Time{Time: transition}},
+				{Type: resourcesv1alpha1.ResourcesHealthy, Status: status, Message: message, LastTransitionTime: metav1.Time{Time: transition}},
+				{Type: resourcesv1alpha1.ResourcesProgressing, Status: gardencorev1beta1.ConditionFalse, LastTransitionTime: metav1.Time{Time: transition}},
+			},
+		},
+	}
+}
+
+func TestCheckManagedResourcesHealthy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := resourcesv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		hibernated  bool
+		mrs         []*resourcesv1alpha1.ManagedResource
+		wantRequeue bool
+		wantErr     string
+	}{
+		{
+			name: "healthy",
+			mrs: []*resourcesv1alpha1.ManagedResource{
+				managedResource(v1alpha1.SeedAuthResourceName, true, now),
+				managedResource(v1alpha1.ShootAuthResourceName, true, now),
+			},
+		},
+		{
+			name:        "unhealthy seed managed resource",
+			mrs:         []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, false, now), managedResource(v1alpha1.ShootAuthResourceName, true, now)},
+			wantRequeue: true,
+			wantErr:     `kube-jwt-authn-webhook`,
+		},
+		{
+			name:    "unhealthy for longer than the health timeout",
+			mrs:     []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, true, now), managedResource(v1alpha1.ShootAuthResourceName, false, now.Add(-time.Hour))},
+			wantErr: "did not become healthy within 2m0s",
+		},
+		{
+			name:       "shoot managed resource is not checked for hibernated shoots",
+			hibernated: true,
+			mrs:        []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, true, now), managedResource(v1alpha1.ShootAuthResourceName, false, now)},
+		},
+		{
+			name:    "missing managed resource",
+			mrs:     []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, true, now)},
+			wantErr: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&resourcesv1alpha1.ManagedResource{})
+			for _, mr := range tt.mrs {
+				builder = builder.WithObjects(mr)
+			}
+
+			a := &actuator{
+				client:   builder.Build(),
+				recorder: record.NewFakeRecorder(10),
+				config: config.ControllerConfiguration{
+					ManagedResources: &config.ManagedResources{HealthTimeout: &metav1.Duration{Duration: 2 * time.Minute}},
+				},
+			}
+			ex := &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: "authn", Namespace: testSeedNamespace}}
+			cluster := &controller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}
+			if tt.hibernated {
+				cluster.Shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: ptr.To(true)}
+				cluster.Shoot.Status.IsHibernated = true
+			}
+
+			err := a.checkManagedResourcesHealthy(context.Background(), logr.Discard(), ex, cluster)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkManagedResourcesHealthy() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkManagedResourcesHealthy() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			var requeue *reconcilerutils.RequeueAfterError
+			if errors.As(err, &requeue) != tt.wantRequeue {
+				t.Errorf("checkManagedResourcesHealthy() error = %v, want requeue %v", err, tt.wantRequeue)
+			}
+		})
+	}
+}
+
+func TestGroupRoleBindingControllerDefaults(t *testing.T) {
+	grc := groupRoleBindingController(&config.ControllerConfiguration{})
+
+	if grc.Mode != config.GroupRoleBindingControllerModeDeployment {
+		t.Errorf("mode = %s, want %s", grc.Mode, config.GroupRoleBindingControllerModeDeployment)
+	}
+	if !slices.Equal(grc.ExcludeNamespaces, []string{"kube-system", "kube-public", "kube-node-lease", "default"}) {
+		t.Errorf("exclude namespaces = %v, want the defaults", grc.ExcludeNamespaces)
+	}
+	if !slices.Equal(grc.ExpectedGroups, []string{"admin", "edit", "view"}) {
+		t.Errorf("expected groups = %v, want the defaults", grc.ExpectedGroups)
+	}
+
+	configured := &config.GroupRoleBindingController{Mode: config.GroupRoleBindingControllerModeExtension}
+	if got := groupRoleBindingController(&config.ControllerConfiguration{GroupRoleBindingController: configured}); got != configured {
+		t.Errorf("groupRoleBindingController() = %v, want the configured controller", got)
+	}
+}
+
+func seedScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, extensionsv1alpha1.AddToScheme, resourcesv1alpha1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return scheme
+}
+
+func TestDeleteResources(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		mrs         []*resourcesv1alpha1.ManagedResource
+		blocked     bool
+		deletedAt   time.Time
+		wantRequeue bool
+		wantErr     string
+	}{
+		{
+			name:      "deleted",
+			mrs:       []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, true, now), managedResource(v1alpha1.ShootAuthResourceName, true, now)},
+			deletedAt: now,
+		},
+		{
+			name:        "still exists",
+			mrs:         []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, true, now), managedResource(v1alpha1.ShootAuthResourceName, true, now)},
+			blocked:     true,
+			deletedAt:   now,
+			wantRequeue: true,
+			wantErr:     "waiting for managed resources " + v1alpha1.ShootAuthResourceName + " to be deleted",
+		},
+		{
+			name:      "timeout exceeded",
+			mrs:       []*resourcesv1alpha1.ManagedResource{managedResource(v1alpha1.SeedAuthResourceName, true, now), managedResource(v1alpha1.ShootAuthResourceName, true, now)},
+			blocked:   true,
+			deletedAt: now.Add(-time.Hour),
+			wantErr:   "managed resources " + v1alpha1.ShootAuthResourceName + " were not deleted within 2m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(seedScheme(t))
+			for _, mr := range tt.mrs {
+				// the shoot managed resource is held back by the resource manager
+				if tt.blocked && mr.Name == v1alpha1.ShootAuthResourceName {
+					mr.Finalizers = []string{testFinalizer}
+				}
+				builder = builder.WithObjects(mr)
+			}
+
+			a := &actuator{
+				client:   builder.Build(),
+				recorder: record.NewFakeRecorder(10),
+				config: config.ControllerConfiguration{
+					ManagedResources: &config.ManagedResources{DeletionTimeout: &metav1.Duration{Duration: 2 * time.Minute}},
+				},
+			}
+			ex := &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{
+				Name:              "authn",
+				Namespace:         testSeedNamespace,
+				DeletionTimestamp: &metav1.Time{Time: tt.deletedAt},
+			}}
+
+			err := a.deleteResources(context.Background(), logr.Discard(), ex)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("deleteResources() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("deleteResources() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			var requeue *reconcilerutils.RequeueAfterError
+			if errors.As(err, &requeue) != tt.wantRequeue {
+				t.Errorf("deleteResources() error = %v, want requeue %v", err, tt.wantRequeue)
+			}
+		})
+	}
+}
+
+func TestForceDelete(t *testing.T) {
+	ctx := context.Background()
+	scheme := seedScheme(t)
+
+	shoot, err := json.Marshal(&gardencorev1beta1.Shoot{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+		ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-project"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var seedObjects []client.Object
+	seedObjects = append(seedObjects, &extensionsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testSeedNamespace},
+		Spec:       extensionsv1alpha1.ClusterSpec{Shoot: runtime.RawExtension{Raw: shoot}},
+	})
+	for _, name := range []string{v1alpha1.ShootAuthResourceName, v1alpha1.SeedAuthResourceName} {
+		mr := managedResource(name, false, time.Now())
+		mr.Finalizers = []string{testFinalizer}
+		mr.Spec.SecretRefs = []corev1.LocalObjectReference{{Name: "managedresource-" + name + "-1234"}}
+		seedObjects = append(seedObjects, mr, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:       "managedresource-" + name + "-1234",
+			Namespace:  testSeedNamespace,
+			Finalizers: []string{testFinalizer},
+		}})
+	}
+	seedObjects = append(seedObjects,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: shootAccessSecretName(), Namespace: testSeedNamespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      breakGlassSecretName + "-1234",
+			Namespace: testSeedNamespace,
+			Labels: map[string]string{
+				secretsmanager.LabelKeyName:            breakGlassSecretName,
+				secretsmanager.LabelKeyManagedBy:       "secrets-manager",
+				secretsmanager.LabelKeyManagerIdentity: secretsManagerIdentity,
+			},
+		}},
+	)
+	seedClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(seedObjects...).Build()
+
+	gardenObjects := []client.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "garden-project--shoot--break-glass", Namespace: "fits-break-glass"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shoot.oidc-kubeconfig", Namespace: "garden-project"}},
+	}
+	gardenClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gardenObjects...).Build()
+
+	a := &actuator{
+		client: seedClient,
+		config: config.ControllerConfiguration{
+			BreakGlass:     &config.BreakGlass{GardenNamespace: "fits-break-glass"},
+			OIDCKubeconfig: &config.OIDCKubeconfig{},
+		},
+		gardenClient: gardenClient,
+		tenants:      newTenantResolver(nil, nil),
+	}
+	ex := &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: "authn", Namespace: testSeedNamespace}}
+
+	if err := a.ForceDelete(ctx, logr.Discard(), ex); err != nil {
+		t.Fatalf("ForceDelete() error = %v", err)
+	}
+
+	for _, obj := range seedObjects[1:] {
+		if err := seedClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+			t.Errorf("%T %s was not deleted, error = %v", obj, obj.GetName(), err)
+		}
+	}
+	for _, obj := range gardenObjects {
+		if err := gardenClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+			t.Errorf("%T %s was not deleted from the garden, error = %v", obj, obj.GetName(), err)
+		}
+	}
+}
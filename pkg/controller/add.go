@@ -2,12 +2,24 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/extension"
+	extensionspredicate "github.com/gardener/gardener/extensions/pkg/predicate"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 
 	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
 )
@@ -15,9 +27,9 @@ import (
 const (
 	// Type is the type of Extension resource.
 	Type = "fits-authn"
-	// ControllerName is the name of the registry cache service controller.
+	// ControllerName is the name of the fits-authn controller.
 	ControllerName = "fits-authn"
-	// FinalizerSuffix is the finalizer suffix for the registry cache service controller.
+	// FinalizerSuffix is the finalizer suffix for the fits-authn controller.
 	FinalizerSuffix = "fits-authn"
 )
 
@@ -26,16 +38,19 @@ var (
 	DefaultAddOptions = AddOptions{}
 )
 
-// AddOptions are options to apply when adding the registry cache service controller to the manager.
+// AddOptions are options to apply when adding the fits-authn controller to the manager.
 type AddOptions struct {
 	// ControllerOptions contains options for the controller.
 	ControllerOptions controller.Options
-	// Config contains configuration for the registry cache service.
+	// Config contains configuration for the fits-authn controller.
 	Config config.ControllerConfiguration
 	// IgnoreOperationAnnotation specifies whether to ignore the operation annotation or not.
 	IgnoreOperationAnnotation bool
 	// ExtensionClass defines the extension class this extension is responsible for.
 	ExtensionClass extensionsv1alpha1.ExtensionClass
+	// GardenCluster is the garden cluster, it is used to look up the projects of shoots and to publish
+	// the break-glass credentials.
+	GardenCluster cluster.Cluster
 }
 
 // AddToManager adds a controller with the default Options to the given Controller Manager.
@@ -46,14 +61,62 @@ func AddToManager(ctx context.Context, mgr manager.Manager) error {
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
+	// when the group rolebindings are managed by the extension itself, the extensions need to be
+	// resynced periodically in order to pick up namespaces that were created in the meantime
+	var resync time.Duration
+	if grc := opts.Config.GroupRoleBindingController; grc != nil && grc.Mode == config.GroupRoleBindingControllerModeExtension && grc.SyncPeriod != nil {
+		resync = grc.SyncPeriod.Duration
+	}
+
+	var (
+		gardenReader client.Reader
+		gardenClient client.Client
+	)
+	if opts.GardenCluster != nil {
+		gardenReader = opts.GardenCluster.GetAPIReader()
+		gardenClient = opts.GardenCluster.GetClient()
+	}
+
+	// without the operation annotation being ignored, the extensions are only reconciled when gardener asks for it,
+	// changes of the cluster like the hibernation of the shoot need to be picked up nevertheless
+	var watchBuilder extensionscontroller.WatchBuilder
+	if !opts.IgnoreOperationAnnotation {
+		watchBuilder.Register(func(c controller.Controller) error {
+			return c.Watch(source.Kind[client.Object](
+				mgr.GetCache(),
+				&extensionsv1alpha1.Cluster{},
+				handler.EnqueueRequestsFromMapFunc(extension.ClusterToExtensionMapper(mgr.GetClient(), extensionspredicate.HasType(Type), extensionspredicate.HasClass(opts.ExtensionClass))),
+				clusterChangedPredicate(),
+			))
+		})
+	}
+
+	// the project members are bound in the shoot, so changes in the garden dashboard need to be picked up
+	if opts.Config.ProjectMembers != nil && opts.GardenCluster != nil {
+		clusterMapper := extension.ClusterToExtensionMapper(mgr.GetClient(), extensionspredicate.HasType(Type), extensionspredicate.HasClass(opts.ExtensionClass))
+		watchBuilder.Register(func(c controller.Controller) error {
+			return c.Watch(source.Kind[client.Object](
+				opts.GardenCluster.GetCache(),
+				&gardencorev1beta1.Project{},
+				handler.EnqueueRequestsFromMapFunc(projectToExtensionMapper(mgr.GetClient(), clusterMapper)),
+				projectMembersChangedPredicate(),
+			))
+		})
+	}
+
+	if err := metrics.Registry.Register(newShootCollector(mgr.GetCache(), serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(), opts.ExtensionClass, mgr.GetLogger().WithName("shoot-metrics"))); err != nil {
+		return fmt.Errorf("could not register shoot metrics: %w", err)
+	}
+
 	return extension.Add(mgr, extension.AddArgs{
-		Actuator:          NewActuator(mgr, opts.Config),
+		Actuator:          NewActuator(mgr, gardenReader, gardenClient, opts.Config),
 		ControllerOptions: opts.ControllerOptions,
 		Name:              ControllerName,
 		FinalizerSuffix:   FinalizerSuffix,
-		Resync:            0,
-		Predicates:        extension.DefaultPredicates(ctx, mgr, DefaultAddOptions.IgnoreOperationAnnotation),
+		Resync:            resync,
+		Predicates:        extension.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
 		Type:              Type,
+		WatchBuilder:      watchBuilder,
 		ExtensionClasses:  []extensionsv1alpha1.ExtensionClass{opts.ExtensionClass},
 	})
 }
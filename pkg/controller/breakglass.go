@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	"github.com/go-logr/logr"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	secretsManagerIdentity = "fits-authn"
+
+	breakGlassSecretName  = "fits-authn-break-glass"
+	breakGlassUser        = "fits-authn:break-glass"
+	breakGlassClusterRole = "fits-authn:break-glass"
+	breakGlassMountPath   = "/etc/break-glass"
+)
+
+// breakGlass references the break-glass credentials of a shoot in the seed.
+type breakGlass struct {
+	// secretName is the name of the seed secret that contains the tokens.
+	secretName string
+	// extensionName is the name of the Extension resource the uses of the credentials are recorded at.
+	extensionName string
+}
+
+// reconcileBreakGlass generates the break-glass credentials of the shoot and publishes them to the garden.
+// The credentials are rotated if the Extension resource carries the rotation operation annotation.
+func (a *actuator) reconcileBreakGlass(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension, cluster *controller.Cluster) (*breakGlass, error) {
+	if a.gardenClient == nil {
+		return nil, fmt.Errorf("break-glass credentials cannot be published without access to the garden cluster")
+	}
+
+	rotate := ex.Annotations[v1alpha1.AnnotationOperation] == v1alpha1.OperationRotateBreakGlassCredentials
+
+	rotation := secretsmanager.Config{}
+	if rotate {
+		log.Info("rotating break-glass credentials")
+		rotation.SecretNamesToTimes = map[string]time.Time{breakGlassSecretName: time.Now().UTC()}
+	}
+
+	sm, err := secretsmanager.New(ctx, log, clock.RealClock{}, a.client, ex.Namespace, secretsManagerIdentity, rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := sm.Generate(ctx, &secretsutils.StaticTokenSecretConfig{
+		Name: breakGlassSecretName,
+		Tokens: map[string]secretsutils.TokenConfig{
+			breakGlassUser: {
+				Username: breakGlassUser,
+				Groups:   []string{breakGlassUser},
+			},
+		},
+	}, secretsmanager.Rotate(secretsmanager.InPlace), secretsmanager.Persist())
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate break-glass credentials: %w", err)
+	}
+
+	staticToken, err := secretsutils.LoadStaticTokenFromCSV(breakGlassSecretName, secret.Data[secretsutils.DataKeyStaticTokenCSV])
+	if err != nil {
+		return nil, fmt.Errorf("unable to read break-glass credentials")
+	}
+	token, err := staticToken.GetTokenForUsername(breakGlassUser)
+	if err != nil {
+		return nil, err
+	}
+
+	gardenSecret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      breakGlassGardenSecretName(cluster),
+			Namespace: a.config.BreakGlass.GardenNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "fits-authn",
+			},
+			Annotations: map[string]string{
+				"authn.fits.cloud/shoot-namespace": cluster.Shoot.Namespace,
+				"authn.fits.cloud/shoot-name":      cluster.Shoot.Name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte(token.Username),
+			"token":    []byte(token.Token),
+		},
+	}
+
+	if err := a.gardenClient.Patch(ctx, gardenSecret, client.Apply, client.ForceOwnership, client.FieldOwner(secretsManagerIdentity)); err != nil {
+		return nil, fmt.Errorf("unable to publish break-glass credentials: %w", err)
+	}
+
+	if err := sm.Cleanup(ctx); err != nil {
+		return nil, err
+	}
+
+	if rotate {
+		patch := client.MergeFrom(ex.DeepCopy())
+		delete(ex.Annotations, v1alpha1.AnnotationOperation)
+		if err := a.client.Patch(ctx, ex, patch); err != nil {
+			return nil, fmt.Errorf("unable to remove operation annotation: %w", err)
+		}
+
+		log.Info("break-glass credentials rotated successfully")
+	}
+
+	return &breakGlass{
+		secretName:    secret.Name,
+		extensionName: ex.Name,
+	}, nil
+}
+
+// deleteBreakGlass removes the break-glass credentials of the shoot from the seed and the garden.
+func (a *actuator) deleteBreakGlass(ctx context.Context, log logr.Logger, namespace string, cluster *controller.Cluster) error {
+	// without generating any secret, the cleanup removes all secrets of the secrets manager
+	sm, err := secretsmanager.New(ctx, log, clock.RealClock{}, a.client, namespace, secretsManagerIdentity, secretsmanager.Config{})
+	if err != nil {
+		return err
+	}
+	if err := sm.Cleanup(ctx); err != nil {
+		return err
+	}
+
+	if a.config.BreakGlass == nil || a.gardenClient == nil || cluster == nil {
+		return nil
+	}
+
+	return client.IgnoreNotFound(a.gardenClient.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      breakGlassGardenSecretName(cluster),
+			Namespace: a.config.BreakGlass.GardenNamespace,
+		},
+	}))
+}
+
+func breakGlassGardenSecretName(cluster *controller.Cluster) string {
+	return fmt.Sprintf("%s--%s--break-glass", cluster.Shoot.Namespace, cluster.Shoot.Name)
+}
+
+// breakGlassShootObjects returns the dedicated cluster role of the break-glass user in the shoot.
+func breakGlassShootObjects() []client.Object {
+	return []client.Object{
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: breakGlassClusterRole,
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"*"},
+					Resources: []string{"*"},
+					Verbs:     []string{"*"},
+				},
+				{
+					NonResourceURLs: []string{"*"},
+					Verbs:           []string{"*"},
+				},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: breakGlassClusterRole,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.GroupKind,
+					Name:     breakGlassUser,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     breakGlassClusterRole,
+			},
+		},
+	}
+}
+
+// breakGlassSeedObjects mounts the break-glass credentials into the token webhook and allows it to record events.
+func breakGlassSeedObjects(bg *breakGlass, webhookDeployment *appsv1.Deployment) []client.Object {
+	template := &webhookDeployment.Spec.Template
+	template.Labels["networking.gardener.cloud/to-runtime-apiserver"] = "allowed"
+	template.Spec.ServiceAccountName = webhookDeployment.Name
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: "break-glass",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: bg.secretName,
+			},
+		},
+	})
+
+	c := &template.Spec.Containers[0]
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      "break-glass",
+		MountPath: breakGlassMountPath,
+		ReadOnly:  true,
+	})
+	c.Env = append(c.Env,
+		corev1.EnvVar{
+			Name:  "BREAK_GLASS_TOKEN_FILE",
+			Value: breakGlassMountPath + "/" + secretsutils.DataKeyStaticTokenCSV,
+		},
+		corev1.EnvVar{
+			Name:  "EXTENSION_NAME",
+			Value: bg.extensionName,
+		},
+		corev1.EnvVar{
+			Name: "POD_NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+			},
+		},
+	)
+
+	return []client.Object{
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      webhookDeployment.Name,
+				Namespace: webhookDeployment.Namespace,
+			},
+		},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      webhookDeployment.Name,
+				Namespace: webhookDeployment.Namespace,
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"events"},
+					Verbs:     []string{"create", "patch", "update"},
+				},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      webhookDeployment.Name,
+				Namespace: webhookDeployment.Namespace,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      webhookDeployment.Name,
+					Namespace: webhookDeployment.Namespace,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     webhookDeployment.Name,
+			},
+		},
+	}
+}
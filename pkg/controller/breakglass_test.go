@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestBreakGlassShootObjects(t *testing.T) {
+	objects := breakGlassShootObjects()
+	if len(objects) != 2 {
+		t.Fatalf("breakGlassShootObjects() returned %d objects, want 2", len(objects))
+	}
+
+	role, ok := objects[0].(*rbacv1.ClusterRole)
+	if !ok || role.Name != breakGlassClusterRole {
+		t.Fatalf("object 0 = %T %s, want cluster role %s", objects[0], objects[0].GetName(), breakGlassClusterRole)
+	}
+
+	binding, ok := objects[1].(*rbacv1.ClusterRoleBinding)
+	if !ok {
+		t.Fatalf("object 1 is a %T, want a cluster role binding", objects[1])
+	}
+	if binding.RoleRef.Name != breakGlassClusterRole {
+		t.Errorf("binding refers to %s, want %s", binding.RoleRef.Name, breakGlassClusterRole)
+	}
+	want := []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: breakGlassUser}}
+	if !slices.Equal(binding.Subjects, want) {
+		t.Errorf("binding subjects = %v, want %v", binding.Subjects, want)
+	}
+}
+
+func TestBreakGlassSeedObjects(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-jwt-authn-webhook", Namespace: testSeedNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "kube-jwt-authn-webhook"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "kube-jwt-authn-webhook"}},
+				},
+			},
+		},
+	}
+
+	objects := breakGlassSeedObjects(&breakGlass{secretName: "fits-authn-break-glass-1234", extensionName: "authn"}, deployment)
+
+	template := deployment.Spec.Template
+	if template.Spec.ServiceAccountName != deployment.Name {
+		t.Errorf("service account name = %q, want %q", template.Spec.ServiceAccountName, deployment.Name)
+	}
+	if template.Labels["networking.gardener.cloud/to-runtime-apiserver"] != "allowed" {
+		t.Error("webhook is not allowed to reach the seed api server")
+	}
+	if len(template.Spec.Volumes) != 1 || template.Spec.Volumes[0].Secret == nil || template.Spec.Volumes[0].Secret.SecretName != "fits-authn-break-glass-1234" {
+		t.Errorf("volumes = %v, want the break-glass secret", template.Spec.Volumes)
+	}
+
+	env := map[string]corev1.EnvVar{}
+	for _, e := range template.Spec.Containers[0].Env {
+		env[e.Name] = e
+	}
+	if got := env["BREAK_GLASS_TOKEN_FILE"].Value; got != breakGlassMountPath+"/"+secretsutils.DataKeyStaticTokenCSV {
+		t.Errorf("BREAK_GLASS_TOKEN_FILE = %q", got)
+	}
+	if got := env["EXTENSION_NAME"].Value; got != "authn" {
+		t.Errorf("EXTENSION_NAME = %q, want authn", got)
+	}
+	if ref := env["POD_NAMESPACE"].ValueFrom; ref == nil || ref.FieldRef == nil || ref.FieldRef.FieldPath != "metadata.namespace" {
+		t.Errorf("POD_NAMESPACE = %v, want the namespace of the pod", env["POD_NAMESPACE"])
+	}
+
+	if len(objects) != 3 {
+		t.Fatalf("breakGlassSeedObjects() returned %d objects, want 3", len(objects))
+	}
+	if sa, ok := objects[0].(*corev1.ServiceAccount); !ok || sa.Name != deployment.Name || sa.Namespace != testSeedNamespace {
+		t.Errorf("object 0 = %T %s, want the service account of the webhook", objects[0], objects[0].GetName())
+	}
+	role, ok := objects[1].(*rbacv1.Role)
+	if !ok {
+		t.Fatalf("object 1 is a %T, want a role", objects[1])
+	}
+	if len(role.Rules) != 1 || !slices.Equal(role.Rules[0].Resources, []string{"events"}) || !slices.Contains(role.Rules[0].Verbs, "create") {
+		t.Errorf("role rules = %v, want to create events", role.Rules)
+	}
+	binding, ok := objects[2].(*rbacv1.RoleBinding)
+	if !ok {
+		t.Fatalf("object 2 is a %T, want a role binding", objects[2])
+	}
+	if binding.RoleRef.Name != role.Name || len(binding.Subjects) != 1 || binding.Subjects[0].Kind != rbacv1.ServiceAccountKind || binding.Subjects[0].Name != deployment.Name {
+		t.Errorf("role binding = %v, want the service account bound to the role", binding)
+	}
+}
+
+func TestReconcileBreakGlassRotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := extensionsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ex := &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: "authn", Namespace: testSeedNamespace}}
+	seedClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ex).Build()
+
+	var published *corev1.Secret
+	gardenClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		// the fake client does not support server-side apply
+		Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+			published = obj.(*corev1.Secret)
+			return nil
+		},
+	}).Build()
+
+	a := &actuator{
+		client:       seedClient,
+		config:       config.ControllerConfiguration{BreakGlass: &config.BreakGlass{GardenNamespace: "fits-break-glass"}},
+		gardenClient: gardenClient,
+	}
+	cluster := &controller.Cluster{Shoot: &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-project"}}}
+
+	reconcile := func() (*breakGlass, string) {
+		t.Helper()
+		if err := seedClient.Get(ctx, client.ObjectKeyFromObject(ex), ex); err != nil {
+			t.Fatal(err)
+		}
+		bg, err := a.reconcileBreakGlass(ctx, logr.Discard(), ex, cluster)
+		if err != nil {
+			t.Fatalf("reconcileBreakGlass() error = %v", err)
+		}
+		if published == nil || published.Namespace != "fits-break-glass" || published.Name != "garden-project--shoot--break-glass" {
+			t.Fatalf("published secret = %v, want fits-break-glass/garden-project--shoot--break-glass", published)
+		}
+		return bg, string(published.Data["token"])
+	}
+
+	bg, token := reconcile()
+	if bg.extensionName != "authn" || bg.secretName == "" {
+		t.Errorf("break glass = %+v", bg)
+	}
+
+	if unchanged, unchangedToken := reconcile(); unchanged.secretName != bg.secretName || unchangedToken != token {
+		t.Error("credentials changed without rotation")
+	}
+
+	ex.Annotations = map[string]string{v1alpha1.AnnotationOperation: v1alpha1.OperationRotateBreakGlassCredentials}
+	if err := seedClient.Update(ctx, ex); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, rotatedToken := reconcile()
+	if rotated.secretName == bg.secretName || rotatedToken == token {
+		t.Error("credentials were not rotated")
+	}
+
+	if err := seedClient.Get(ctx, client.ObjectKeyFromObject(ex), ex); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ex.Annotations[v1alpha1.AnnotationOperation]; ok {
+		t.Error("operation annotation was not removed after the rotation")
+	}
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+)
+
+// clusterChangedPredicate returns a predicate that only lets changes of the Cluster resource pass which affect
+// the objects the actuator deploys, such that hibernation and wake-up take effect immediately.
+func clusterChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, ok := e.ObjectOld.(*extensionsv1alpha1.Cluster)
+			if !ok {
+				return false
+			}
+			newCluster, ok := e.ObjectNew.(*extensionsv1alpha1.Cluster)
+			if !ok {
+				return false
+			}
+
+			oldShoot, err := extensions.ShootFromCluster(oldCluster)
+			if err != nil || oldShoot == nil {
+				return false
+			}
+			newShoot, err := extensions.ShootFromCluster(newCluster)
+			if err != nil || newShoot == nil {
+				return false
+			}
+
+			return shootChanged(oldShoot, newShoot)
+		},
+	}
+}
+
+// shootChanged returns true if the shoot changed in a way that requires the extension to be reconciled.
+func shootChanged(oldShoot, newShoot *gardencorev1beta1.Shoot) bool {
+	return hibernationEnabled(oldShoot) != hibernationEnabled(newShoot) ||
+		oldShoot.Status.IsHibernated != newShoot.Status.IsHibernated ||
+		oldShoot.Spec.Kubernetes.Version != newShoot.Spec.Kubernetes.Version ||
+		oldShoot.Annotations[tag.ClusterTenant] != newShoot.Annotations[tag.ClusterTenant] ||
+		!apiequality.Semantic.DeepEqual(oldShoot.Spec.ControlPlane, newShoot.Spec.ControlPlane) ||
+		// the published kubeconfig contains the addresses of the shoot
+		!apiequality.Semantic.DeepEqual(oldShoot.Status.AdvertisedAddresses, newShoot.Status.AdvertisedAddresses)
+}
+
+func hibernationEnabled(shoot *gardencorev1beta1.Shoot) bool {
+	return shoot.Spec.Hibernation != nil && shoot.Spec.Hibernation.Enabled != nil && *shoot.Spec.Hibernation.Enabled
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	infoConfigMapName      = "fits-authn-info"
+	infoConfigMapNamespace = metav1.NamespacePublic
+	infoDataKey            = "info.json"
+)
+
+// Info contains the effective authentication settings of a shoot. It is published in the shoot, such that
+// tooling can discover how to log in.
+type Info struct {
+	// Issuer is the url of the oidc issuer.
+	Issuer string `json:"issuer"`
+	// ClientID is the client id the tokens must be issued for.
+	ClientID string `json:"clientID"`
+	// GroupsPrefix is the application prefix of the token groups that refer to the cluster.
+	GroupsPrefix string `json:"groupsPrefix"`
+	// Tenant is the tenant that owns the cluster.
+	Tenant string `json:"tenant"`
+	// AccessScope defines which users of the tenant are allowed to access the cluster.
+	AccessScope string `json:"accessScope"`
+	// Roles are the cluster roles that the groups "<cluster>-<namespace>-<role>" are bound to in the namespaces.
+	Roles []string `json:"roles,omitempty"`
+	// Suspended is true if the authentication with the token webhook is suspended.
+	Suspended bool `json:"suspended,omitempty"`
+	// AdditionalTenants are the tenants besides the owning tenant whose members may access the cluster.
+	AdditionalTenants []InfoTenant `json:"additionalTenants,omitempty"`
+}
+
+// InfoTenant describes the access of an additional tenant.
+type InfoTenant struct {
+	// Name is the name of the tenant.
+	Name string `json:"name"`
+	// RoleMapping maps the roles of the tenant's groups to the cluster roles that are bound in the namespaces.
+	RoleMapping map[string]string `json:"roleMapping"`
+}
+
+// infoShootObjects returns the config map that contains the effective authentication settings of the shoot
+// and allows all authenticated users to read it.
+func infoShootObjects(cc *config.ControllerConfiguration, auth *config.Auth, authConfig *authn.AuthnConfig, tenant string) ([]client.Object, error) {
+	accessScope := authConfig.AccessScope
+	if accessScope == "" {
+		accessScope = authn.AccessScopeTenant
+	}
+
+	info := Info{
+		Issuer:       authConfig.Issuer,
+		ClientID:     authConfig.ClientID,
+		GroupsPrefix: auth.GroupsPrefix,
+		Tenant:       tenant,
+		AccessScope:  string(accessScope),
+		Suspended:    authConfig.Suspended,
+		Roles:        groupRoleBindingController(cc).ExpectedGroups,
+	}
+	for _, t := range additionalTenantRoles(authConfig) {
+		info.AdditionalTenants = append(info.AdditionalTenants, InfoTenant(t))
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode authentication info: %w", err)
+	}
+
+	return []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      infoConfigMapName,
+				Namespace: infoConfigMapNamespace,
+			},
+			Data: map[string]string{
+				infoDataKey: string(data),
+			},
+		},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      infoConfigMapName,
+				Namespace: infoConfigMapNamespace,
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups:     []string{""},
+					Resources:     []string{"configmaps"},
+					ResourceNames: []string{infoConfigMapName},
+					Verbs:         []string{"get", "list", "watch"},
+				},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      infoConfigMapName,
+				Namespace: infoConfigMapNamespace,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.GroupKind,
+					Name:     "system:authenticated",
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     infoConfigMapName,
+			},
+		},
+	}, nil
+}
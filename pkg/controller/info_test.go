@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInfoShootObjects(t *testing.T) {
+	auth := &config.Auth{GroupsPrefix: "k8s"}
+
+	tests := []struct {
+		name       string
+		cc         *config.ControllerConfiguration
+		authConfig *authn.AuthnConfig
+		want       Info
+	}{
+		{
+			name:       "defaults",
+			cc:         &config.ControllerConfiguration{},
+			authConfig: &authn.AuthnConfig{Issuer: testIssuer, ClientID: "kubernetes"},
+			want: Info{
+				Issuer:       testIssuer,
+				ClientID:     "kubernetes",
+				GroupsPrefix: "k8s",
+				Tenant:       "tnnt",
+				AccessScope:  string(authn.AccessScopeTenant),
+				Roles:        []string{"admin", "edit", "view"},
+			},
+		},
+		{
+			name: "configured roles",
+			cc: &config.ControllerConfiguration{
+				GroupRoleBindingController: &config.GroupRoleBindingController{ExpectedGroups: []string{"admin", "view"}},
+			},
+			authConfig: &authn.AuthnConfig{Issuer: testIssuer, ClientID: "kubernetes"},
+			want: Info{
+				Issuer:       testIssuer,
+				ClientID:     "kubernetes",
+				GroupsPrefix: "k8s",
+				Tenant:       "tnnt",
+				AccessScope:  string(authn.AccessScopeTenant),
+				Roles:        []string{"admin", "view"},
+			},
+		},
+		{
+			name: "additional tenants and suspended",
+			cc: &config.ControllerConfiguration{
+				GroupRoleBindingController: &config.GroupRoleBindingController{ExpectedGroups: []string{"admin"}},
+			},
+			authConfig: &authn.AuthnConfig{
+				Issuer:            testIssuer,
+				ClientID:          "kubernetes",
+				Suspended:         true,
+				AdditionalTenants: []authn.TenantAccess{{Name: "OTHER", RoleMapping: map[string]string{"admin": "view"}}},
+			},
+			want: Info{
+				Issuer:            testIssuer,
+				ClientID:          "kubernetes",
+				GroupsPrefix:      "k8s",
+				Tenant:            "tnnt",
+				AccessScope:       string(authn.AccessScopeTenant),
+				Roles:             []string{"admin"},
+				Suspended:         true,
+				AdditionalTenants: []InfoTenant{{Name: "other", RoleMapping: map[string]string{"admin": "view"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := infoShootObjects(tt.cc, auth, tt.authConfig, "tnnt")
+			if err != nil {
+				t.Fatalf("infoShootObjects() error = %v", err)
+			}
+			if len(objects) != 3 {
+				t.Fatalf("infoShootObjects() returned %d objects, want 3", len(objects))
+			}
+
+			cm, ok := objects[0].(*corev1.ConfigMap)
+			if !ok || cm.Name != infoConfigMapName || cm.Namespace != infoConfigMapNamespace {
+				t.Fatalf("object 0 = %T %s, want the info config map", objects[0], objects[0].GetName())
+			}
+
+			var got Info
+			if err := json.Unmarshal([]byte(cm.Data[infoDataKey]), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("info = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	configlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+	configv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+const (
+	oidcKubeconfigSuffix  = "oidc-kubeconfig"
+	oidcKubeconfigDataKey = "kubeconfig"
+	// kubeconfigRequeueInterval is the interval in which the kubeconfig is published again while the addresses or
+	// the cluster ca of the shoot are not available yet.
+	kubeconfigRequeueInterval = 30 * time.Second
+)
+
+// publishOIDCKubeconfig stores a kubeconfig that logs in with the kubectl oidc-login plugin in the project
+// namespace of the shoot. It is updated on every reconciliation, such that it follows changes of the issuer
+// and the addresses of the shoot. The reconciliation is requeued until the shoot has advertised addresses and its
+// cluster ca is published, such that a new shoot gets its kubeconfig without waiting for the next reconciliation
+// by gardener.
+func (a *actuator) publishOIDCKubeconfig(ctx context.Context, log logr.Logger, authConfig *authn.AuthnConfig, cluster *controller.Cluster) error {
+	if a.gardenClient == nil {
+		return fmt.Errorf("the kubeconfig cannot be published without access to the garden cluster")
+	}
+
+	if len(cluster.Shoot.Status.AdvertisedAddresses) == 0 {
+		log.Info("shoot has no advertised addresses yet, requeueing kubeconfig")
+		return &reconcilerutils.RequeueAfterError{
+			Cause:        fmt.Errorf("shoot has no advertised addresses yet, the kubeconfig cannot be published"),
+			RequeueAfter: kubeconfigRequeueInterval,
+		}
+	}
+
+	ca := &corev1.ConfigMap{}
+	caName := gutil.ComputeShootProjectResourceName(cluster.Shoot.Name, gutil.ShootProjectConfigMapSuffixCACluster)
+	if err := a.gardenReader.Get(ctx, client.ObjectKey{Namespace: cluster.Shoot.Namespace, Name: caName}, ca); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to read cluster ca of the shoot: %w", err)
+		}
+	}
+	if ca.Data[secretsutils.DataKeyCertificateCA] == "" {
+		log.Info("cluster ca of the shoot is not published yet, requeueing kubeconfig", "name", caName)
+		return &reconcilerutils.RequeueAfterError{
+			Cause:        fmt.Errorf("cluster ca %s of the shoot is not published yet, the kubeconfig cannot be published", caName),
+			RequeueAfter: kubeconfigRequeueInterval,
+		}
+	}
+
+	kubeconfig, err := oidcKubeconfig(a.config.OIDCKubeconfig, authConfig, cluster, []byte(ca.Data[secretsutils.DataKeyCertificateCA]))
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gutil.ComputeShootProjectResourceName(cluster.Shoot.Name, oidcKubeconfigSuffix),
+			Namespace: cluster.Shoot.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "fits-authn",
+			},
+		},
+		Data: map[string]string{
+			oidcKubeconfigDataKey: string(kubeconfig),
+		},
+	}
+
+	if err := a.gardenClient.Patch(ctx, cm, client.Apply, client.ForceOwnership, client.FieldOwner(secretsManagerIdentity)); err != nil {
+		return fmt.Errorf("unable to publish kubeconfig: %w", err)
+	}
+
+	log.Info("kubeconfig published successfully", "name", cm.Name)
+
+	return nil
+}
+
+// deleteOIDCKubeconfig removes the published kubeconfig of the shoot.
+func (a *actuator) deleteOIDCKubeconfig(ctx context.Context, cluster *controller.Cluster) error {
+	if a.config.OIDCKubeconfig == nil || a.gardenClient == nil {
+		return nil
+	}
+
+	return client.IgnoreNotFound(a.gardenClient.Delete(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gutil.ComputeShootProjectResourceName(cluster.Shoot.Name, oidcKubeconfigSuffix),
+			Namespace: cluster.Shoot.Namespace,
+		},
+	}))
+}
+
+// oidcKubeconfig returns a kubeconfig with a context for every advertised address of the shoot, the external
+// address is the current context if available.
+func oidcKubeconfig(cfg *config.OIDCKubeconfig, authConfig *authn.AuthnConfig, cluster *controller.Cluster, ca []byte) ([]byte, error) {
+	var (
+		userName = "oidc"
+		args     = []string{
+			"oidc-login",
+			"get-token",
+			"--oidc-issuer-url=" + authConfig.Issuer,
+			"--oidc-client-id=" + authConfig.ClientID,
+		}
+	)
+	for _, scope := range cfg.ExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+
+	kubeconfig := &configv1.Config{
+		AuthInfos: []configv1.NamedAuthInfo{
+			{
+				Name: userName,
+				AuthInfo: configv1.AuthInfo{
+					Exec: &configv1.ExecConfig{
+						APIVersion:      "client.authentication.k8s.io/v1beta1",
+						Command:         "kubectl",
+						Args:            args,
+						InteractiveMode: configv1.IfAvailableExecInteractiveMode,
+					},
+				},
+			},
+		},
+	}
+
+	for _, address := range cluster.Shoot.Status.AdvertisedAddresses {
+		if address.Name == v1beta1constants.AdvertisedAddressServiceAccountIssuer {
+			continue
+		}
+
+		name := cluster.Shoot.Namespace + "--" + cluster.Shoot.Name + "-" + address.Name
+
+		c := configv1.Cluster{Server: address.URL}
+		// the wildcard endpoint serves a certificate of a public ca
+		if address.Name != v1beta1constants.AdvertisedAddressWildcardTLSSeedBound {
+			c.CertificateAuthorityData = ca
+		}
+
+		kubeconfig.Clusters = append(kubeconfig.Clusters, configv1.NamedCluster{Name: name, Cluster: c})
+		kubeconfig.Contexts = append(kubeconfig.Contexts, configv1.NamedContext{
+			Name: name,
+			Context: configv1.Context{
+				Cluster:  name,
+				AuthInfo: userName,
+			},
+		})
+
+		if kubeconfig.CurrentContext == "" || address.Name == v1beta1constants.AdvertisedAddressExternal {
+			kubeconfig.CurrentContext = name
+		}
+	}
+
+	out, err := runtime.Encode(configlatest.Codec, kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode kubeconfig: %w", err)
+	}
+
+	return out, nil
+}
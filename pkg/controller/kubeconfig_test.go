@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+const testCA = "-----BEGIN CERTIFICATE-----\nca\n-----END CERTIFICATE-----\n"
+
+func TestPublishOIDCKubeconfig(t *testing.T) {
+	addresses := []gardencorev1beta1.ShootAdvertisedAddress{
+		{Name: v1beta1constants.AdvertisedAddressExternal, URL: "https://api.shoot.example.com"},
+		{Name: v1beta1constants.AdvertisedAddressInternal, URL: "https://api.internal.example.com"},
+	}
+	clusterCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "shoot.ca-cluster", Namespace: "garden-project"},
+		Data:       map[string]string{"ca.crt": testCA},
+	}
+
+	tests := []struct {
+		name        string
+		addresses   []gardencorev1beta1.ShootAdvertisedAddress
+		objects     []client.Object
+		wantRequeue bool
+	}{
+		{
+			name:      "published",
+			addresses: addresses,
+			objects:   []client.Object{clusterCA},
+		},
+		{
+			name:        "no advertised addresses",
+			objects:     []client.Object{clusterCA},
+			wantRequeue: true,
+		},
+		{
+			name:        "cluster ca not published",
+			addresses:   addresses,
+			wantRequeue: true,
+		},
+		{
+			name:      "cluster ca without certificate",
+			addresses: addresses,
+			objects: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot.ca-cluster", Namespace: "garden-project"},
+			}},
+			wantRequeue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published *corev1.ConfigMap
+			gardenClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(tt.objects...).WithInterceptorFuncs(interceptor.Funcs{
+				// the fake client does not support server-side apply
+				Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					published = obj.(*corev1.ConfigMap)
+					return nil
+				},
+			}).Build()
+
+			a := &actuator{
+				config:       config.ControllerConfiguration{OIDCKubeconfig: &config.OIDCKubeconfig{}},
+				gardenReader: gardenClient,
+				gardenClient: gardenClient,
+			}
+			cluster := &controller.Cluster{Shoot: &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-project"},
+				Status:     gardencorev1beta1.ShootStatus{AdvertisedAddresses: tt.addresses},
+			}}
+
+			err := a.publishOIDCKubeconfig(context.Background(), logr.Discard(), &authn.AuthnConfig{Issuer: testIssuer, ClientID: "kubernetes"}, cluster)
+			if tt.wantRequeue {
+				var requeue *reconcilerutils.RequeueAfterError
+				if !errors.As(err, &requeue) {
+					t.Fatalf("publishOIDCKubeconfig() error = %v, want requeue", err)
+				}
+				if published != nil {
+					t.Errorf("kubeconfig %s was published, want none", published.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("publishOIDCKubeconfig() error = %v", err)
+			}
+
+			if published == nil || published.Name != "shoot.oidc-kubeconfig" {
+				t.Fatalf("published kubeconfig = %v, want shoot.oidc-kubeconfig", published)
+			}
+			kubeconfig := published.Data[oidcKubeconfigDataKey]
+			for _, want := range []string{"certificate-authority-data", "https://api.shoot.example.com", "current-context: garden-project--shoot-external", "--oidc-issuer-url=" + testIssuer} {
+				if !strings.Contains(kubeconfig, want) {
+					t.Errorf("kubeconfig does not contain %q:\n%s", want, kubeconfig)
+				}
+			}
+		})
+	}
+}
+
+func TestShootChanged(t *testing.T) {
+	shoot := func(modify func(*gardencorev1beta1.Shoot)) *gardencorev1beta1.Shoot {
+		s := &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.32.0"}}}
+		if modify != nil {
+			modify(s)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*gardencorev1beta1.Shoot)
+		want   bool
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name:   "hibernated",
+			modify: func(s *gardencorev1beta1.Shoot) { s.Status.IsHibernated = true },
+			want:   true,
+		},
+		{
+			name:   "kubernetes version",
+			modify: func(s *gardencorev1beta1.Shoot) { s.Spec.Kubernetes.Version = "1.33.0" },
+			want:   true,
+		},
+		{
+			name: "advertised addresses",
+			modify: func(s *gardencorev1beta1.Shoot) {
+				s.Status.AdvertisedAddresses = []gardencorev1beta1.ShootAdvertisedAddress{{Name: v1beta1constants.AdvertisedAddressExternal, URL: "https://api.shoot.example.com"}}
+			},
+			want: true,
+		},
+		{
+			name:   "unrelated status",
+			modify: func(s *gardencorev1beta1.Shoot) { s.Status.ObservedGeneration = 2 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shootChanged(shoot(nil), shoot(tt.modify)); got != tt.want {
+				t.Errorf("shootChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
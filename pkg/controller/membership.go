@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/tokenreview"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	metalAPISecretName        = "kube-jwt-authn-webhook-metalapi-secret"
+	metalV2SecretName         = "kube-jwt-authn-webhook-metalv2-secret"
+	staticGroupsConfigMapName = "kube-jwt-authn-webhook-static-groups"
+	staticGroupsKey           = "groups.yaml"
+	staticGroupsMountPath     = "/etc/authn-webhook/static"
+)
+
+// membershipResources contains everything the authn webhook needs to talk to a membership backend.
+type membershipResources struct {
+	env          []corev1.EnvVar
+	objects      []client.Object
+	volumes      []corev1.Volume
+	volumeMounts []corev1.VolumeMount
+}
+
+func membershipObjects(backend *config.MembershipBackend, namespace string) (*membershipResources, error) {
+	if backend == nil {
+		return nil, fmt.Errorf("no membership backend configured")
+	}
+
+	res := &membershipResources{}
+
+	switch backend.Type {
+	case config.MembershipBackendMetalAPI:
+		res.env = []corev1.EnvVar{
+			{Name: "GROUP_RESOLVER", Value: "metal-api"},
+			secretEnv("METAL_URL", metalAPISecretName, "metalapi-url"),
+			secretEnv("METAL_HMAC", metalAPISecretName, "metalapi-hmac"),
+			secretEnv("METAL_HMACAUTHTYPE", metalAPISecretName, "metalapi-authtype"),
+		}
+		res.objects = []client.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metalAPISecretName,
+					Namespace: namespace,
+				},
+				StringData: map[string]string{
+					"metalapi-url":      backend.MetalAPI.URL,
+					"metalapi-hmac":     backend.MetalAPI.HMAC,
+					"metalapi-authtype": backend.MetalAPI.AuthType,
+				},
+			},
+		}
+	case config.MembershipBackendMetalV2:
+		res.env = []corev1.EnvVar{
+			{Name: "GROUP_RESOLVER", Value: "metal-v2"},
+			secretEnv("METAL_V2_URL", metalV2SecretName, "url"),
+			secretEnv("METAL_V2_TOKEN", metalV2SecretName, "token"),
+		}
+		res.objects = []client.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metalV2SecretName,
+					Namespace: namespace,
+				},
+				StringData: map[string]string{
+					"url":   backend.MetalV2.URL,
+					"token": backend.MetalV2.Token,
+				},
+			},
+		}
+	case config.MembershipBackendStatic:
+		content, err := yaml.Marshal(tokenreview.StaticGroups{
+			Users:  backend.Static.Users,
+			Groups: backend.Static.Groups,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode static groups: %w", err)
+		}
+
+		res.env = []corev1.EnvVar{
+			{Name: "GROUP_RESOLVER", Value: "static"},
+			{Name: "STATIC_GROUPS_FILE", Value: path.Join(staticGroupsMountPath, staticGroupsKey)},
+		}
+		res.objects = []client.Object{
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      staticGroupsConfigMapName,
+					Namespace: namespace,
+				},
+				Data: map[string]string{
+					staticGroupsKey: string(content),
+				},
+			},
+		}
+		res.volumes = []corev1.Volume{
+			{
+				Name: "static-groups",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: staticGroupsConfigMapName},
+					},
+				},
+			},
+		}
+		res.volumeMounts = []corev1.VolumeMount{
+			{
+				Name:      "static-groups",
+				MountPath: staticGroupsMountPath,
+				ReadOnly:  true,
+			},
+		}
+	case config.MembershipBackendNone:
+		res.env = []corev1.EnvVar{
+			{Name: "GROUP_RESOLVER", Value: "none"},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported membership backend %q", backend.Type)
+	}
+
+	return res, nil
+}
+
+func secretEnv(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
+	"github.com/gardener/gardener/pkg/extensions"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	phaseShootAccessSecret     = "shoot_access_secret"
+	phaseManagedResourceCreate = "managed_resource_create"
+	phaseDeleteWait            = "delete_wait"
+)
+
+var (
+	reconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fits_authn_extension_reconcile_phase_duration_seconds",
+		Help:    "Duration of the phases of the extension reconciliation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"phase"})
+
+	shootsDesc = prometheus.NewDesc(
+		"fits_authn_extension_shoots",
+		"Number of shoots of the extension by tenant and auth profile.",
+		[]string{"tenant", "profile"}, nil,
+	)
+
+	hibernatedShootsDesc = prometheus.NewDesc(
+		"fits_authn_extension_hibernated_shoots",
+		"Number of hibernated shoots of the extension.",
+		nil, nil,
+	)
+
+	reconcilesSkipped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "fits_authn_extension_reconciles_skipped_total",
+		Help: "Number of reconciliations that did not rewrite the managed resources because their inputs were unchanged.",
+	})
+)
+
+// collectTimeout is the maximum duration of reading the shoots from the cache on a scrape.
+const collectTimeout = 10 * time.Second
+
+// RegisterMetrics registers the metrics of the extension controller at the given registerer. The shoot metrics are
+// registered when the controller is added to the manager, because they are read from its cache.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{reconcilePhaseDuration, reconcilesSkipped} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observePhase records the duration of a reconciliation phase that started at the given time.
+func observePhase(phase string, start time.Time) {
+	reconcilePhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+// shootCollector counts the shoots of the extension from the Extension and Cluster resources in the cache of the
+// manager on every scrape. In contrast to gauges that are updated by the reconciliation, the numbers are complete
+// right after a restart or a change of the leader, when healthy extensions are not reconciled.
+type shootCollector struct {
+	reader  client.Reader
+	decoder runtime.Decoder
+	class   extensionsv1alpha1.ExtensionClass
+	log     logr.Logger
+}
+
+func newShootCollector(reader client.Reader, decoder runtime.Decoder, class extensionsv1alpha1.ExtensionClass, log logr.Logger) *shootCollector {
+	if class == "" {
+		class = extensionsv1alpha1.ExtensionClassShoot
+	}
+	return &shootCollector{reader: reader, decoder: decoder, class: class, log: log}
+}
+
+// Describe implements prometheus.Collector.
+func (c *shootCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- shootsDesc
+	ch <- hibernatedShootsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *shootCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	exList := &extensionsv1alpha1.ExtensionList{}
+	if err := c.reader.List(ctx, exList); err != nil {
+		ch <- prometheus.NewInvalidMetric(shootsDesc, fmt.Errorf("unable to list extensions: %w", err))
+		return
+	}
+
+	clusters := &extensionsv1alpha1.ClusterList{}
+	if err := c.reader.List(ctx, clusters); err != nil {
+		ch <- prometheus.NewInvalidMetric(hibernatedShootsDesc, fmt.Errorf("unable to list clusters: %w", err))
+		return
+	}
+
+	hibernatedNamespaces := map[string]bool{}
+	for i := range clusters.Items {
+		shoot, err := extensions.ShootFromCluster(&clusters.Items[i])
+		if err != nil || shoot == nil {
+			continue
+		}
+		hibernatedNamespaces[clusters.Items[i].Name] = controller.IsHibernated(&controller.Cluster{Shoot: shoot})
+	}
+
+	type labels struct {
+		tenant  string
+		profile string
+	}
+
+	var (
+		counts     = map[labels]int{}
+		hibernated = 0
+	)
+	for _, ex := range exList.Items {
+		if ex.Spec.Type != Type || extensionsv1alpha1helper.GetExtensionClassOrDefault(ex.Spec.Class) != c.class || ex.DeletionTimestamp != nil {
+			continue
+		}
+
+		// extensions that were not reconciled yet are counted without tenant and profile
+		var l labels
+		if ex.Status.ProviderStatus != nil && len(ex.Status.ProviderStatus.Raw) > 0 {
+			status := &authn.AuthnStatus{}
+			if _, _, err := c.decoder.Decode(ex.Status.ProviderStatus.Raw, nil, status); err != nil {
+				c.log.Error(err, "unable to decode provider status", "namespace", ex.Namespace)
+			} else {
+				l = labels{tenant: strings.ToLower(status.Tenant), profile: status.Profile}
+			}
+		}
+
+		counts[l]++
+		if hibernatedNamespaces[ex.Namespace] {
+			hibernated++
+		}
+	}
+
+	for l, n := range counts {
+		ch <- prometheus.MustNewConstMetric(shootsDesc, prometheus.GaugeValue, float64(n), l.tenant, l.profile)
+	}
+	ch <- prometheus.MustNewConstMetric(hibernatedShootsDesc, prometheus.GaugeValue, float64(hibernated))
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/install"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+func TestShootCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{extensionsv1alpha1.AddToScheme, install.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	extension := func(namespace, extensionType, tenant, profile string) client.Object {
+		ex := &extensionsv1alpha1.Extension{
+			ObjectMeta: metav1.ObjectMeta{Name: "authn", Namespace: namespace},
+			Spec:       extensionsv1alpha1.ExtensionSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: extensionType}},
+		}
+		if tenant != "" {
+			data, err := json.Marshal(&v1alpha1.AuthnStatus{
+				TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "AuthnStatus"},
+				Tenant:   tenant,
+				Profile:  profile,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ex.Status.ProviderStatus = &runtime.RawExtension{Raw: data}
+		}
+		return ex
+	}
+	cluster := func(namespace string, hibernated bool) client.Object {
+		shoot := &gardencorev1beta1.Shoot{
+			TypeMeta: metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+		}
+		if hibernated {
+			shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: ptr.To(true)}
+			shoot.Status.IsHibernated = true
+		}
+		data, err := json.Marshal(shoot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec:       extensionsv1alpha1.ClusterSpec{Shoot: runtime.RawExtension{Raw: data}},
+		}
+	}
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		extension("shoot--a--one", Type, "TNNT", "default"),
+		extension("shoot--a--two", Type, "tnnt", "default"),
+		extension("shoot--b--one", Type, "other", "partner"),
+		extension("shoot--b--two", Type, "", ""),
+		extension("shoot--c--one", "other-extension", "tnnt", "default"),
+		cluster("shoot--a--one", false),
+		cluster("shoot--a--two", true),
+		cluster("shoot--b--one", false),
+		cluster("shoot--c--one", true),
+	).Build()
+
+	collector := newShootCollector(reader, serializer.NewCodecFactory(scheme).UniversalDecoder(), "", logr.Discard())
+
+	want := `
+# HELP fits_authn_extension_hibernated_shoots Number of hibernated shoots of the extension.
+# TYPE fits_authn_extension_hibernated_shoots gauge
+fits_authn_extension_hibernated_shoots 1
+# HELP fits_authn_extension_shoots Number of shoots of the extension by tenant and auth profile.
+# TYPE fits_authn_extension_shoots gauge
+fits_authn_extension_shoots{profile="",tenant=""} 1
+fits_authn_extension_shoots{profile="default",tenant="tnnt"} 2
+fits_authn_extension_shoots{profile="partner",tenant="other"} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
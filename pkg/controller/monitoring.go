@@ -0,0 +1,187 @@
+package controller
+
+import (
+	_ "embed"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/component/observability/monitoring/prometheus/shoot"
+	monitoringutils "github.com/gardener/gardener/pkg/component/observability/monitoring/utils"
+	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	metricsPortName = "monitoring"
+	metricsPort     = 2112
+
+	runbookURL = "https://github.com/fi-ts/gardener-extension-authn/blob/master/docs/runbooks.md"
+)
+
+//go:embed assets/fits-authn-dashboard.json
+var dashboard string
+
+// monitoringSeedObjects returns the scrape configuration, the alerting rules and the dashboard of the authn
+// components for the control plane prometheus of the shoot. The metrics of the webhook are only served by the
+// fits-authn-webhook image, so the webhook is not monitored with other images. The alerts are left out if the
+// webhook is not supposed to run.
+func monitoringSeedObjects(namespace string, webhookService *corev1.Service, webhookMetrics, grc, alerts bool) ([]client.Object, error) {
+	var objects []client.Object
+
+	if webhookMetrics {
+		webhookObjects, err := webhookMonitoringObjects(namespace, webhookService, alerts)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, webhookObjects...)
+	}
+
+	if grc {
+		grcService := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "group-rolebinding-controller",
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app": "group-rolebinding-controller",
+				},
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{
+					"app": "group-rolebinding-controller",
+				},
+				Ports: []corev1.ServicePort{
+					{
+						Name:       metricsPortName,
+						Port:       metricsPort,
+						TargetPort: intstr.FromInt32(metricsPort),
+					},
+				},
+			},
+		}
+		if err := gutil.InjectNetworkPolicyAnnotationsForScrapeTargets(grcService, networkingv1.NetworkPolicyPort{
+			Port:     ptr.To(intstr.FromInt32(metricsPort)),
+			Protocol: ptr.To(corev1.ProtocolTCP),
+		}); err != nil {
+			return nil, err
+		}
+
+		objects = append(objects,
+			grcService,
+			&monitoringv1.ServiceMonitor{
+				ObjectMeta: monitoringutils.ConfigObjectMeta(grcService.Name, namespace, shoot.Label),
+				Spec: monitoringv1.ServiceMonitorSpec{
+					Selector: metav1.LabelSelector{MatchLabels: grcService.Labels},
+					Endpoints: []monitoringv1.Endpoint{{
+						Port: metricsPortName,
+						MetricRelabelConfigs: monitoringutils.StandardMetricRelabelConfig(
+							"controller_runtime_reconcile_total",
+							"controller_runtime_reconcile_errors_total",
+							"process_cpu_seconds_total",
+							"process_resident_memory_bytes",
+						),
+					}},
+				},
+			},
+		)
+	}
+
+	return objects, nil
+}
+
+// webhookMonitoringObjects returns the scrape configuration, the dashboard and optionally the alerting rules
+// of the token webhook.
+func webhookMonitoringObjects(namespace string, webhookService *corev1.Service, alerts bool) ([]client.Object, error) {
+	if err := gutil.InjectNetworkPolicyAnnotationsForScrapeTargets(webhookService, networkingv1.NetworkPolicyPort{
+		Port:     ptr.To(intstr.FromInt32(metricsPort)),
+		Protocol: ptr.To(corev1.ProtocolTCP),
+	}); err != nil {
+		return nil, err
+	}
+
+	objects := []client.Object{
+		&monitoringv1.ServiceMonitor{
+			ObjectMeta: monitoringutils.ConfigObjectMeta(webhookService.Name, namespace, shoot.Label),
+			Spec: monitoringv1.ServiceMonitorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: webhookService.Labels},
+				Endpoints: []monitoringv1.Endpoint{{
+					Port: metricsPortName,
+					MetricRelabelConfigs: monitoringutils.StandardMetricRelabelConfig(
+						"authn_webhook_tokenreviews_total",
+						"authn_webhook_tokenreview_duration_seconds_bucket",
+						"authn_webhook_subjectaccessreviews_total",
+						"authn_webhook_subjectaccessreview_duration_seconds_bucket",
+						"authn_webhook_break_glass_logins_total",
+						"process_cpu_seconds_total",
+						"process_resident_memory_bytes",
+					),
+				}},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fits-authn-dashboards",
+				Namespace: namespace,
+				Labels: map[string]string{
+					v1beta1constants.LabelPrefixMonitoringDashboard + shoot.Label: "true",
+				},
+			},
+			Data: map[string]string{
+				"fits-authn-dashboard.json": dashboard,
+			},
+		},
+	}
+
+	if alerts {
+		objects = append(objects, &monitoringv1.PrometheusRule{
+			ObjectMeta: monitoringutils.ConfigObjectMeta("fits-authn", namespace, shoot.Label),
+			Spec: monitoringv1.PrometheusRuleSpec{
+				Groups: []monitoringv1.RuleGroup{{
+					Name: "fits-authn.rules",
+					Rules: []monitoringv1.Rule{
+						{
+							Alert: "AuthnWebhookDown",
+							Expr:  intstr.FromString(`absent(up{job="` + webhookService.Name + `"} == 1)`),
+							For:   ptr.To(monitoringv1.Duration("10m")),
+							Labels: map[string]string{
+								"service":    webhookService.Name,
+								"severity":   "critical",
+								"type":       "seed",
+								"visibility": "operator",
+							},
+							Annotations: map[string]string{
+								"summary":     "Authn webhook is down",
+								"description": "The token webhook of the kube-apiserver cannot be scraped. Users that log in with tokens of the issuer cannot access the cluster.",
+								"runbook_url": runbookURL + "#authnwebhookdown",
+							},
+						},
+						{
+							Alert: "AuthnHighFailureRate",
+							Expr: intstr.FromString(`sum(rate(authn_webhook_tokenreviews_total{result=~"issuer_error|resolver_error"}[10m]))` +
+								` / sum(rate(authn_webhook_tokenreviews_total[10m])) > 0.1`),
+							For: ptr.To(monitoringv1.Duration("15m")),
+							Labels: map[string]string{
+								"service":    webhookService.Name,
+								"severity":   "warning",
+								"type":       "seed",
+								"visibility": "operator",
+							},
+							Annotations: map[string]string{
+								"summary":     "Authn webhook fails to review tokens",
+								"description": "More than 10% of the token reviews fail because the issuer or the membership backend cannot be reached.",
+								"runbook_url": runbookURL + "#authnhighfailurerate",
+							},
+						},
+					},
+				}},
+			},
+		})
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMonitoringSeedObjects(t *testing.T) {
+	tests := []struct {
+		name           string
+		webhookMetrics bool
+		grc            bool
+		alerts         bool
+		want           []string
+	}{
+		{
+			name:           "fits authenticator",
+			webhookMetrics: true,
+			alerts:         true,
+			want:           []string{"ServiceMonitor/shoot-kube-jwt-authn-webhook", "ConfigMap/fits-authn-dashboards", "PrometheusRule/shoot-fits-authn"},
+		},
+		{
+			name:           "fits authenticator without running webhook",
+			webhookMetrics: true,
+			want:           []string{"ServiceMonitor/shoot-kube-jwt-authn-webhook", "ConfigMap/fits-authn-dashboards"},
+		},
+		{
+			name:   "other authenticator",
+			alerts: true,
+		},
+		{
+			name:   "other authenticator with group rolebinding controller",
+			grc:    true,
+			alerts: true,
+			want:   []string{"Service/group-rolebinding-controller", "ServiceMonitor/shoot-group-rolebinding-controller"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookService := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "kube-jwt-authn-webhook", Namespace: testSeedNamespace, Labels: map[string]string{"app": "kube-jwt-authn-webhook"}},
+			}
+
+			objects, err := monitoringSeedObjects(testSeedNamespace, webhookService, tt.webhookMetrics, tt.grc, tt.alerts)
+			if err != nil {
+				t.Fatalf("monitoringSeedObjects() error = %v", err)
+			}
+
+			var got []string
+			for _, obj := range objects {
+				var kind string
+				switch obj.(type) {
+				case *monitoringv1.ServiceMonitor:
+					kind = "ServiceMonitor"
+				case *monitoringv1.PrometheusRule:
+					kind = "PrometheusRule"
+				case *corev1.ConfigMap:
+					kind = "ConfigMap"
+				case *corev1.Service:
+					kind = "Service"
+				}
+				got = append(got, kind+"/"+obj.GetName())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("monitoringSeedObjects() = %v, want %v", got, tt.want)
+			}
+
+			_, scraped := webhookService.Annotations["networking.resources.gardener.cloud/from-all-scrape-targets-allowed-ports"]
+			if scraped != tt.webhookMetrics {
+				t.Errorf("webhook service scrape annotation present = %v, want %v", scraped, tt.webhookMetrics)
+			}
+		})
+	}
+}
+
+func TestAuthnHighFailureRate(t *testing.T) {
+	webhookService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-jwt-authn-webhook", Namespace: testSeedNamespace},
+	}
+
+	objects, err := monitoringSeedObjects(testSeedNamespace, webhookService, true, false, true)
+	if err != nil {
+		t.Fatalf("monitoringSeedObjects() error = %v", err)
+	}
+
+	var expr string
+	for _, obj := range objects {
+		rule, ok := obj.(*monitoringv1.PrometheusRule)
+		if !ok {
+			continue
+		}
+		for _, group := range rule.Spec.Groups {
+			for _, r := range group.Rules {
+				if r.Alert == "AuthnHighFailureRate" {
+					expr = r.Expr.String()
+				}
+			}
+		}
+	}
+
+	match := regexp.MustCompile(`result=~"([^"]+)"`).FindStringSubmatch(expr)
+	if match == nil {
+		t.Fatalf("alert expression %q does not select the results", expr)
+	}
+	// prometheus anchors the regular expressions of label matchers
+	results := regexp.MustCompile("^(?:" + match[1] + ")$")
+
+	tests := []struct {
+		result string
+		want   bool
+	}{
+		{result: "issuer_error", want: true},
+		{result: "resolver_error", want: true},
+		{result: "error"},
+		{result: "invalid_token"},
+		{result: "suspended"},
+		{result: "unauthorized"},
+		{result: "authenticated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			if got := results.MatchString(tt.result); got != tt.want {
+				t.Errorf("alert counts result %q = %v, want %v", tt.result, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/validation"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// TokenWebhookConfigFile is the path of the token webhook kubeconfig in the kube-apiserver container.
+	TokenWebhookConfigFile     = "/etc/webhook/config/authn-webhook-config.json"
+	tokenWebhookConfigFileFlag = "--authentication-token-webhook-config-file="
+
+	// structuredAuthenticationVolumeName is the name of the kube-apiserver volume that gardener uses
+	// to mount the structured authentication configuration.
+	structuredAuthenticationVolumeName = "authentication-config"
+	structuredAuthenticationDataKey    = "config.yaml"
+)
+
+// nativeAuthentication returns the authentication that gardener configures for the kube-apiserver of the shoot.
+func nativeAuthentication(ctx context.Context, c client.Client, cluster *controller.Cluster, namespace string) (validation.NativeAuthentication, error) {
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer}, deployment); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return validation.NativeAuthentication{}, err
+		}
+		deployment = nil
+	}
+
+	return NativeAuthenticationOf(ctx, c, cluster, deployment)
+}
+
+// NativeAuthenticationOf returns the authentication that gardener configures in the given kube-apiserver deployment
+// of the shoot, the deployment is nil if the kube-apiserver is not deployed yet. The structured authentication
+// configuration is read from the config map the kube-apiserver mounts, because the one referenced in the shoot is
+// located in the garden cluster.
+func NativeAuthenticationOf(ctx context.Context, c client.Reader, cluster *controller.Cluster, deployment *appsv1.Deployment) (validation.NativeAuthentication, error) {
+	native := validation.NativeAuthentication{}
+
+	if kapi := cluster.Shoot.Spec.Kubernetes.KubeAPIServer; kapi != nil {
+		native.OIDC = kapi.OIDCConfig
+	}
+
+	if deployment == nil {
+		return native, nil
+	}
+
+	ps := deployment.Spec.Template.Spec
+	if idx := slices.IndexFunc(ps.Containers, func(c corev1.Container) bool { return c.Name == v1beta1constants.DeploymentNameKubeAPIServer }); idx >= 0 {
+		container := ps.Containers[idx]
+		for _, arg := range slices.Concat(container.Command, container.Args) {
+			if value, ok := strings.CutPrefix(arg, tokenWebhookConfigFileFlag); ok && value != TokenWebhookConfigFile {
+				native.TokenWebhookConfigFile = value
+			}
+		}
+	}
+
+	idx := slices.IndexFunc(ps.Volumes, func(v corev1.Volume) bool {
+		return v.Name == structuredAuthenticationVolumeName
+	})
+	if idx < 0 || ps.Volumes[idx].ConfigMap == nil {
+		return native, nil
+	}
+	volume := ps.Volumes[idx]
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: deployment.Namespace, Name: volume.ConfigMap.Name}, cm); err != nil {
+		return native, err
+	}
+
+	var authenticationConfig struct {
+		JWT []struct {
+			Issuer struct {
+				URL string `json:"url"`
+			} `json:"issuer"`
+		} `json:"jwt"`
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data[structuredAuthenticationDataKey]), &authenticationConfig); err != nil {
+		return native, fmt.Errorf("unable to parse structured authentication configuration: %w", err)
+	}
+
+	for _, jwt := range authenticationConfig.JWT {
+		native.StructuredIssuers = append(native.StructuredIssuers, jwt.Issuer.URL)
+	}
+
+	return native, nil
+}
+
+// TokenWebhookConfigured returns true if the kube-apiserver of a shoot with the given provider config uses the
+// token webhook. A suspended token webhook is only kept to accept the break-glass credentials.
+func TokenWebhookConfigured(authnConfig *authn.AuthnConfig, cfg *config.ControllerConfiguration) bool {
+	return !authnConfig.Suspended || cfg.BreakGlass != nil
+}
+
+// ValidateShootAuthentication returns an error if the native authentication of the shoot cannot be combined with
+// the token webhook of the given provider config. A token webhook that is not configured in the kube-apiserver
+// does not conflict with the native authentication.
+func ValidateShootAuthentication(authnConfig *authn.AuthnConfig, cfg *config.ControllerConfiguration, native validation.NativeAuthentication) error {
+	if !TokenWebhookConfigured(authnConfig, cfg) {
+		return nil
+	}
+
+	if errs := validation.ValidateNativeAuthentication(authnConfig.Issuer, native); len(errs) > 0 {
+		return fmt.Errorf("shoot authentication conflicts with the fits-authn extension: %w", errs.ToAggregate())
+	}
+
+	return nil
+}
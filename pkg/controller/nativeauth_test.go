@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testIssuer        = "https://issuer.example.com"
+	testSeedNamespace = "shoot--project--shoot"
+)
+
+func kubeAPIServerDeployment(command []string, authenticationConfigMap string) *appsv1.Deployment {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver", Namespace: testSeedNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "kube-apiserver", Command: command}},
+				},
+			},
+		},
+	}
+	if authenticationConfigMap != "" {
+		d.Spec.Template.Spec.Volumes = append(d.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: structuredAuthenticationVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: authenticationConfigMap}},
+			},
+		})
+	}
+	return d
+}
+
+func authenticationConfigMap(name, issuer string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testSeedNamespace},
+		Data: map[string]string{
+			structuredAuthenticationDataKey: "jwt:\n- issuer:\n    url: " + issuer + "\n",
+		},
+	}
+}
+
+func TestValidateShootAuthentication(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		kubeAPI      *gardencorev1beta1.KubeAPIServerConfig
+		deployment   *appsv1.Deployment
+		wantIssuers  []string
+		wantConflict bool
+	}{
+		{
+			name:       "no native authentication",
+			deployment: kubeAPIServerDeployment(nil, ""),
+		},
+		{
+			name:       "kube-apiserver not deployed yet",
+			deployment: nil,
+		},
+		{
+			name: "oidc config with another issuer and groups prefix",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To("https://other.example.com"), GroupsPrefix: ptr.To("oidc:")},
+			},
+			deployment: kubeAPIServerDeployment(nil, ""),
+		},
+		{
+			name: "oidc config with the issuer of the extension",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To(testIssuer + "/"), GroupsPrefix: ptr.To("oidc:")},
+			},
+			deployment:   kubeAPIServerDeployment(nil, ""),
+			wantConflict: true,
+		},
+		{
+			name: "oidc config without groups prefix",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To("https://other.example.com")},
+			},
+			deployment:   kubeAPIServerDeployment(nil, ""),
+			wantConflict: true,
+		},
+		{
+			name: "structured authentication with another issuer",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				StructuredAuthentication: &gardencorev1beta1.StructuredAuthentication{ConfigMapName: "auth"},
+			},
+			deployment:  kubeAPIServerDeployment(nil, "auth-other"),
+			wantIssuers: []string{"https://other.example.com"},
+		},
+		{
+			name: "structured authentication with the issuer of the extension",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				StructuredAuthentication: &gardencorev1beta1.StructuredAuthentication{ConfigMapName: "auth"},
+			},
+			deployment:   kubeAPIServerDeployment(nil, "auth-same"),
+			wantIssuers:  []string{testIssuer},
+			wantConflict: true,
+		},
+		{
+			name:       "own token webhook",
+			deployment: kubeAPIServerDeployment([]string{"kube-apiserver", tokenWebhookConfigFileFlag + TokenWebhookConfigFile}, ""),
+		},
+		{
+			name:         "foreign token webhook",
+			deployment:   kubeAPIServerDeployment([]string{"kube-apiserver", tokenWebhookConfigFileFlag + "/etc/other/webhook.json"}, ""),
+			wantConflict: true,
+		},
+	}
+
+	modes := []struct {
+		suffix     string
+		suspended  bool
+		breakGlass bool
+	}{
+		{suffix: ""},
+		{suffix: " while suspended", suspended: true},
+		{suffix: " while suspended with break-glass", suspended: true, breakGlass: true},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range modes {
+			t.Run(tt.name+mode.suffix, func(t *testing.T) {
+				builder := fake.NewClientBuilder().WithObjects(
+					authenticationConfigMap("auth-other", "https://other.example.com"),
+					authenticationConfigMap("auth-same", testIssuer),
+				)
+				if tt.deployment != nil {
+					builder = builder.WithObjects(tt.deployment)
+				}
+				c := builder.Build()
+
+				cluster := &controller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{Kubernetes: gardencorev1beta1.Kubernetes{KubeAPIServer: tt.kubeAPI}},
+					},
+				}
+
+				native, err := nativeAuthentication(ctx, c, cluster, testSeedNamespace)
+				if err != nil {
+					t.Fatalf("nativeAuthentication() error = %v", err)
+				}
+				if len(native.StructuredIssuers) != len(tt.wantIssuers) || (len(tt.wantIssuers) > 0 && native.StructuredIssuers[0] != tt.wantIssuers[0]) {
+					t.Errorf("structured issuers = %v, want %v", native.StructuredIssuers, tt.wantIssuers)
+				}
+
+				cfg := &config.ControllerConfiguration{}
+				if mode.breakGlass {
+					cfg.BreakGlass = &config.BreakGlass{GardenNamespace: "break-glass"}
+				}
+
+				err = ValidateShootAuthentication(&authn.AuthnConfig{Issuer: testIssuer, Suspended: mode.suspended}, cfg, native)
+				if wantErr := tt.wantConflict && (!mode.suspended || mode.breakGlass); (err != nil) != wantErr {
+					t.Errorf("ValidateShootAuthentication() error = %v, wantErr %v", err, wantErr)
+				}
+			})
+		}
+	}
+}
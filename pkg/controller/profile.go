@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+)
+
+// selectProfile returns the auth profile of a shoot. A profile selected in the provider config takes precedence,
+// otherwise the first profile containing the tenant of the shoot is used, falling back to the default profile.
+// The name of the default profile is config.DefaultProfileName.
+func selectProfile(cc *config.ControllerConfiguration, authConfig *authn.AuthnConfig, tenant string) (string, *config.Auth, error) {
+	if authConfig.Profile != "" {
+		for i := range cc.Profiles {
+			if cc.Profiles[i].Name == authConfig.Profile {
+				return cc.Profiles[i].Name, &cc.Profiles[i].Auth, nil
+			}
+		}
+		return "", nil, fmt.Errorf("auth profile %q does not exist", authConfig.Profile)
+	}
+
+	for i := range cc.Profiles {
+		if slices.ContainsFunc(cc.Profiles[i].Tenants, func(t string) bool { return strings.EqualFold(t, tenant) }) {
+			return cc.Profiles[i].Name, &cc.Profiles[i].Auth, nil
+		}
+	}
+
+	return config.DefaultProfileName, &cc.Auth, nil
+}
+
+// applyProfileDefaults sets the issuer and the client id of the profile if the provider config does not contain them.
+func applyProfileDefaults(authConfig *authn.AuthnConfig, auth *config.Auth) {
+	if authConfig.Issuer == "" {
+		authConfig.Issuer = auth.Issuer
+	}
+	if authConfig.ClientID == "" {
+		authConfig.ClientID = auth.ClientID
+	}
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+)
+
+func TestSelectProfile(t *testing.T) {
+	cc := &config.ControllerConfiguration{
+		Auth: config.Auth{Issuer: "https://default.example.com"},
+		Profiles: []config.AuthProfile{
+			{Name: "first", Tenants: []string{"TNNT"}, Auth: config.Auth{Issuer: "https://first.example.com"}},
+			{Name: "second", Tenants: []string{"other"}, Auth: config.Auth{Issuer: "https://second.example.com"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		profile    string
+		tenant     string
+		want       string
+		wantIssuer string
+		wantErr    bool
+	}{
+		{
+			name:       "explicit profile",
+			profile:    "second",
+			tenant:     "tnnt",
+			want:       "second",
+			wantIssuer: "https://second.example.com",
+		},
+		{
+			name:       "tenant match",
+			tenant:     "tnnt",
+			want:       "first",
+			wantIssuer: "https://first.example.com",
+		},
+		{
+			name:       "default profile",
+			tenant:     "unknown",
+			want:       config.DefaultProfileName,
+			wantIssuer: "https://default.example.com",
+		},
+		{
+			name:    "unknown profile",
+			profile: "unknown",
+			tenant:  "tnnt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, auth, err := selectProfile(cc, &authn.AuthnConfig{Profile: tt.profile}, tt.tenant)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("selectProfile() = %s, want error", name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectProfile() error = %v", err)
+			}
+
+			if name != tt.want || auth.Issuer != tt.wantIssuer {
+				t.Errorf("selectProfile() = %s with issuer %s, want %s with issuer %s", name, auth.Issuer, tt.want, tt.wantIssuer)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// projectMemberObjects returns a cluster role binding for every mapped project role that binds the users and groups
+// having the role in the project to the cluster role it is mapped to. Service accounts of the garden cluster
+// are not meaningful in the shoot and are therefore skipped.
+func projectMemberObjects(project *gardencorev1beta1.Project, roleMapping map[string]string) []client.Object {
+	subjects := map[string][]rbacv1.Subject{}
+
+	for _, member := range project.Spec.Members {
+		if member.Kind != rbacv1.UserKind && member.Kind != rbacv1.GroupKind {
+			continue
+		}
+
+		roles := append([]string{member.Role}, member.Roles...)
+		slices.Sort(roles)
+
+		for _, role := range slices.Compact(roles) {
+			if _, ok := roleMapping[role]; !ok {
+				continue
+			}
+
+			subjects[role] = append(subjects[role], rbacv1.Subject{
+				APIGroup: rbacv1.GroupName,
+				Kind:     member.Kind,
+				Name:     member.Name,
+			})
+		}
+	}
+
+	var objects []client.Object
+	for _, role := range slices.Sorted(maps.Keys(subjects)) {
+		objects = append(objects, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "fits-authn:project-member:" + role,
+			},
+			Subjects: subjects[role],
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     roleMapping[role],
+			},
+		})
+	}
+
+	return objects
+}
+
+// projectToExtensionMapper returns a mapper that enqueues the extensions of all shoots of a project, such that
+// changes of the members in the garden dashboard reach the shoots without waiting for the next reconciliation
+// by gardener. The shoots are found through the Cluster resources of the seed, the given mapper maps a Cluster
+// to its extensions.
+func projectToExtensionMapper(reader client.Reader, clusterMapper handler.MapFunc) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		project, ok := obj.(*gardencorev1beta1.Project)
+		if !ok || project.Spec.Namespace == nil {
+			return nil
+		}
+
+		clusters := &extensionsv1alpha1.ClusterList{}
+		if err := reader.List(ctx, clusters); err != nil {
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range clusters.Items {
+			shoot, err := extensions.ShootFromCluster(&clusters.Items[i])
+			if err != nil || shoot == nil || shoot.Namespace != *project.Spec.Namespace {
+				continue
+			}
+			requests = append(requests, clusterMapper(ctx, &clusters.Items[i])...)
+		}
+
+		return requests
+	}
+}
+
+// projectMembersChangedPredicate returns a predicate that only lets changes of the members of a project pass.
+func projectMembersChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldProject, ok := e.ObjectOld.(*gardencorev1beta1.Project)
+			if !ok {
+				return false
+			}
+			newProject, ok := e.ObjectNew.(*gardencorev1beta1.Project)
+			if !ok {
+				return false
+			}
+
+			return !apiequality.Semantic.DeepEqual(oldProject.Spec.Members, newProject.Spec.Members)
+		},
+	}
+}
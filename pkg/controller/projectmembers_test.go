@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestProjectMemberObjects(t *testing.T) {
+	roleMapping := map[string]string{
+		"admin":  "cluster-admin",
+		"viewer": "view",
+	}
+
+	type binding struct {
+		name     string
+		role     string
+		subjects []rbacv1.Subject
+	}
+	user := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: name}
+	}
+	group := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: name}
+	}
+
+	tests := []struct {
+		name    string
+		members []gardencorev1beta1.ProjectMember
+		want    []binding
+	}{
+		{
+			name: "no members",
+		},
+		{
+			name: "users and groups",
+			members: []gardencorev1beta1.ProjectMember{
+				{Subject: user("alice@example.com"), Role: "admin"},
+				{Subject: group("ops"), Role: "viewer"},
+			},
+			want: []binding{
+				{name: "fits-authn:project-member:admin", role: "cluster-admin", subjects: []rbacv1.Subject{user("alice@example.com")}},
+				{name: "fits-authn:project-member:viewer", role: "view", subjects: []rbacv1.Subject{group("ops")}},
+			},
+		},
+		{
+			name: "service accounts are skipped",
+			members: []gardencorev1beta1.ProjectMember{
+				{Subject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "robot", Namespace: "garden-project"}, Role: "admin"},
+				{Subject: user("alice@example.com"), Role: "admin"},
+			},
+			want: []binding{
+				{name: "fits-authn:project-member:admin", role: "cluster-admin", subjects: []rbacv1.Subject{user("alice@example.com")}},
+			},
+		},
+		{
+			name: "role and roles are deduplicated",
+			members: []gardencorev1beta1.ProjectMember{
+				{Subject: user("alice@example.com"), Role: "admin", Roles: []string{"viewer", "admin", "viewer"}},
+			},
+			want: []binding{
+				{name: "fits-authn:project-member:admin", role: "cluster-admin", subjects: []rbacv1.Subject{user("alice@example.com")}},
+				{name: "fits-authn:project-member:viewer", role: "view", subjects: []rbacv1.Subject{user("alice@example.com")}},
+			},
+		},
+		{
+			name: "unmapped roles",
+			members: []gardencorev1beta1.ProjectMember{
+				{Subject: user("alice@example.com"), Role: "owner", Roles: []string{"uam"}},
+				{Subject: user("bob@example.com"), Role: "viewer", Roles: []string{"extension:foo"}},
+			},
+			want: []binding{
+				{name: "fits-authn:project-member:viewer", role: "view", subjects: []rbacv1.Subject{user("bob@example.com")}},
+			},
+		},
+		{
+			name: "sorted by role",
+			members: []gardencorev1beta1.ProjectMember{
+				{Subject: user("bob@example.com"), Role: "viewer"},
+				{Subject: user("alice@example.com"), Role: "admin"},
+				{Subject: group("ops"), Role: "admin"},
+			},
+			want: []binding{
+				{name: "fits-authn:project-member:admin", role: "cluster-admin", subjects: []rbacv1.Subject{user("alice@example.com"), group("ops")}},
+				{name: "fits-authn:project-member:viewer", role: "view", subjects: []rbacv1.Subject{user("bob@example.com")}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &gardencorev1beta1.Project{Spec: gardencorev1beta1.ProjectSpec{Members: tt.members}}
+
+			objects := projectMemberObjects(project, roleMapping)
+			if len(objects) != len(tt.want) {
+				t.Fatalf("projectMemberObjects() returned %d objects, want %d", len(objects), len(tt.want))
+			}
+
+			for i, obj := range objects {
+				crb, ok := obj.(*rbacv1.ClusterRoleBinding)
+				if !ok {
+					t.Fatalf("object %d is a %T, want a cluster role binding", i, obj)
+				}
+				want := tt.want[i]
+				if crb.Name != want.name || crb.RoleRef.Name != want.role || crb.RoleRef.Kind != "ClusterRole" {
+					t.Errorf("binding %d = %s to %s, want %s to %s", i, crb.Name, crb.RoleRef.Name, want.name, want.role)
+				}
+				if !slices.Equal(crb.Subjects, want.subjects) {
+					t.Errorf("binding %s subjects = %v, want %v", crb.Name, crb.Subjects, want.subjects)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectToExtensionMapper(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := extensionsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := func(name, shootNamespace string) client.Object {
+		data, err := json.Marshal(&gardencorev1beta1.Shoot{
+			TypeMeta:   metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+			ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: shootNamespace},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       extensionsv1alpha1.ClusterSpec{Shoot: runtime.RawExtension{Raw: data}},
+		}
+	}
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		cluster("shoot--project--one", "garden-project"),
+		cluster("shoot--project--two", "garden-project"),
+		cluster("shoot--other--one", "garden-other"),
+	).Build()
+
+	// maps every cluster to an extension in the namespace of the cluster
+	clusterMapper := func(_ context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetName(), Name: "authn"}}}
+	}
+
+	project := &gardencorev1beta1.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "project"},
+		Spec:       gardencorev1beta1.ProjectSpec{Namespace: ptr.To("garden-project")},
+	}
+
+	var got []string
+	for _, r := range projectToExtensionMapper(reader, clusterMapper)(context.Background(), project) {
+		got = append(got, r.Namespace)
+	}
+	slices.Sort(got)
+
+	if want := []string{"shoot--project--one", "shoot--project--two"}; !slices.Equal(got, want) {
+		t.Errorf("projectToExtensionMapper() = %v, want %v", got, want)
+	}
+
+	changed := project.DeepCopy()
+	changed.Spec.Members = []gardencorev1beta1.ProjectMember{{Subject: rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice@example.com"}, Role: "admin"}}
+	relabeled := project.DeepCopy()
+	relabeled.Labels = map[string]string{"foo": "bar"}
+
+	p := projectMembersChangedPredicate()
+	if !p.Update(event.UpdateEvent{ObjectOld: project, ObjectNew: changed}) {
+		t.Error("predicate does not pass changes of the members")
+	}
+	if p.Update(event.UpdateEvent{ObjectOld: project, ObjectNew: relabeled}) {
+		t.Error("predicate passes changes that do not affect the members")
+	}
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newShootClient returns a client for the shoot cluster. It authenticates with the token of the given shoot access secret,
+// such that the extension acts with the same permissions as the workloads it would otherwise deploy to the seed.
+func newShootClient(ctx context.Context, c client.Client, namespace, genericKubeconfigSecretName, shootAccessSecretName string) (client.Client, error) {
+	genericKubeconfig := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: genericKubeconfigSecretName}, genericKubeconfig); err != nil {
+		return nil, fmt.Errorf("unable to get generic kubeconfig secret: %w", err)
+	}
+
+	shootAccessSecret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: shootAccessSecretName}, shootAccessSecret); err != nil {
+		return nil, fmt.Errorf("unable to get shoot access secret: %w", err)
+	}
+
+	token := shootAccessSecret.Data[resourcesv1alpha1.DataKeyToken]
+	if len(token) == 0 {
+		return nil, fmt.Errorf("shoot access secret %s does not contain a token yet", shootAccessSecretName)
+	}
+
+	kubeconfig, err := clientcmd.Load(genericKubeconfig.Data[resourcesv1alpha1.DataKeyKubeconfig])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse generic kubeconfig: %w", err)
+	}
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*kubeconfig, nil).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// the generic kubeconfig is meant for pods inside the shoot namespace, so the service name needs to be qualified
+	// and the token file is replaced by the actual token
+	restConfig.Host = fmt.Sprintf("https://%s.%s.svc", v1beta1constants.DeploymentNameKubeAPIServer, namespace)
+	restConfig.BearerTokenFile = ""
+	restConfig.BearerToken = string(token)
+
+	return client.New(restConfig, client.Options{Scheme: kubernetes.ShootScheme})
+}
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/validation"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/version"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/gardener/gardener/pkg/extensions"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	"github.com/go-logr/logr"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// authnStatus returns the provider status of the Extension resource that describes the authentication
+// settings in effect for the shoot.
+func (a *actuator) authnStatus(authConfig *authn.AuthnConfig, auth *config.Auth, native validation.NativeAuthentication, tenant, profile, namespace, configHash, inputHash string) (*v1alpha1.AuthnStatus, error) {
+	authnImage, grcImage, err := componentImages(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &v1alpha1.AuthnStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "AuthnStatus",
+		},
+		Issuers: []string{authConfig.Issuer},
+		Tenant:  tenant,
+		Profile: profile,
+		Images: []v1alpha1.ComponentImage{
+			{
+				Name:  "kube-jwt-authn-webhook",
+				Image: authnImage,
+			},
+		},
+		Webhook: v1alpha1.WebhookStatus{
+			Endpoint:  WebhookURL(namespace, "authenticate"),
+			Mode:      v1alpha1.AuthenticationModeWebhook,
+			Suspended: authConfig.Suspended,
+		},
+		ConfigHash: configHash,
+		InputHash:  inputHash,
+	}
+
+	if groupRoleBindingController(&a.config).Mode == config.GroupRoleBindingControllerModeDeployment {
+		status.Images = append(status.Images, v1alpha1.ComponentImage{
+			Name:  "group-rolebinding-controller",
+			Image: grcImage,
+		})
+	}
+
+	if native.OIDC != nil || len(native.StructuredIssuers) > 0 {
+		status.Webhook.Mode = v1alpha1.AuthenticationModeStructured
+	}
+	if native.OIDC != nil && native.OIDC.IssuerURL != nil {
+		status.Issuers = append(status.Issuers, *native.OIDC.IssuerURL)
+	}
+	for _, issuer := range native.StructuredIssuers {
+		if !slices.Contains(status.Issuers, issuer) {
+			status.Issuers = append(status.Issuers, issuer)
+		}
+	}
+
+	return status, nil
+}
+
+// updateProviderStatus writes the given status to the provider status of the Extension resource.
+func (a *actuator) updateProviderStatus(ctx context.Context, ex *extensionsv1alpha1.Extension, status *v1alpha1.AuthnStatus) error {
+	patch := client.MergeFrom(ex.DeepCopy())
+	ex.Status.ProviderStatus = &runtime.RawExtension{Object: status}
+
+	if err := a.client.Status().Patch(ctx, ex, patch); err != nil {
+		return fmt.Errorf("unable to update provider status: %w", err)
+	}
+
+	return nil
+}
+
+// mutableImageTag is the tag of development builds, whose images change without a change of their reference.
+const mutableImageTag = "latest"
+
+// reconcileInputs are the inputs the objects of the managed resources are rendered from.
+type reconcileInputs struct {
+	AuthnConfig                 *authn.AuthnConfig
+	Auth                        *config.Auth
+	Config                      config.ControllerConfiguration
+	Native                      validation.NativeAuthentication
+	Tenant                      string
+	Profile                     string
+	ShootNamespace              string
+	ShootName                   string
+	Project                     string
+	Hibernated                  bool
+	GenericKubeconfigSecretName string
+	Images                      []string
+	Version                     string
+	BreakGlassSecretName        string
+	ProjectMembers              []gardencorev1beta1.ProjectMember
+}
+
+// inputHash returns a hash of the inputs of the reconciliation. The settings of the controller configuration that
+// do not end up in the managed resources are left out, the auth configuration is covered by the selected profile.
+// The objects also depend on the code of the extension, which is only identified by the resolved image references if
+// they are pinned to a release tag or a digest. For development builds the hash is empty, such that the managed
+// resources are always rewritten.
+func (a *actuator) inputHash(authConfig *authn.AuthnConfig, auth *config.Auth, native validation.NativeAuthentication, cluster *controller.Cluster, tenant, profile string, bg *breakGlass, project *gardencorev1beta1.Project) (string, error) {
+	authnImage, grcImage, err := componentImages(auth)
+	if err != nil {
+		return "", err
+	}
+	if version.Version == mutableImageTag || !pinned(authnImage) || !pinned(grcImage) {
+		return "", nil
+	}
+
+	cfg := *a.config.DeepCopy()
+	cfg.Auth = config.Auth{}
+	cfg.Profiles = nil
+	cfg.HealthCheckConfig = nil
+	cfg.ManagedResources = nil
+
+	inputs := reconcileInputs{
+		AuthnConfig:                 authConfig,
+		Auth:                        auth,
+		Config:                      cfg,
+		Native:                      native,
+		Tenant:                      tenant,
+		Profile:                     profile,
+		ShootNamespace:              cluster.Shoot.Namespace,
+		ShootName:                   cluster.Shoot.Name,
+		Project:                     cluster.Shoot.Annotations[tag.ClusterProject],
+		Hibernated:                  controller.IsHibernated(cluster),
+		GenericKubeconfigSecretName: extensions.GenericTokenKubeconfigSecretNameFromCluster(cluster),
+		Images:                      []string{authnImage, grcImage},
+		Version:                     version.Version,
+	}
+	if bg != nil {
+		inputs.BreakGlassSecretName = bg.secretName
+	}
+	if project != nil {
+		inputs.ProjectMembers = project.Spec.Members
+	}
+
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize reconcile inputs: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// pinned returns true if the given image reference changes with the content of the image, which is the case for
+// digests and all tags except the one of development builds.
+func pinned(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return false
+	}
+	return image[i+1:] != mutableImageTag
+}
+
+// unchanged returns true if the provider status of the Extension resource was written for the given input hash,
+// the managed resources are healthy and the secrets they depend on exist, in which case they do not need to be
+// rewritten.
+func (a *actuator) unchanged(ctx context.Context, log logr.Logger, ex *extensionsv1alpha1.Extension, cluster *controller.Cluster, inputHash string) bool {
+	if inputHash == "" || ex.Status.ProviderStatus == nil || len(ex.Status.ProviderStatus.Raw) == 0 {
+		return false
+	}
+
+	status := &authn.AuthnStatus{}
+	if _, _, err := a.decoder.Decode(ex.Status.ProviderStatus.Raw, nil, status); err != nil {
+		log.Error(err, "unable to decode provider status, updating managed resources")
+		return false
+	}
+	if status.InputHash != inputHash {
+		return false
+	}
+
+	names := []string{v1alpha1.SeedAuthResourceName}
+	if !controller.IsHibernated(cluster) {
+		names = append(names, v1alpha1.ShootAuthResourceName)
+	}
+
+	// the secrets are not covered by the health of the managed resources, e.g. the data of a managed resource is
+	// only applied again once its secrets change
+	secrets := []string{shootAccessSecretName()}
+
+	for _, name := range names {
+		mr := &resourcesv1alpha1.ManagedResource{}
+		if err := a.client.Get(ctx, client.ObjectKey{Namespace: ex.GetNamespace(), Name: name}, mr); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Error(err, "unable to get managed resource", "name", name)
+			}
+			return false
+		}
+
+		if err := health.CheckManagedResource(mr); err != nil {
+			log.Info("managed resource is not healthy, updating managed resources", "name", name, "reason", err.Error())
+			return false
+		}
+
+		for _, ref := range mr.Spec.SecretRefs {
+			secrets = append(secrets, ref.Name)
+		}
+	}
+
+	for _, name := range secrets {
+		if err := a.client.Get(ctx, client.ObjectKey{Namespace: ex.GetNamespace(), Name: name}, &corev1.Secret{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info("secret is missing, updating managed resources", "name", name)
+			} else {
+				log.Error(err, "unable to get secret", "name", name)
+			}
+			return false
+		}
+	}
+
+	return true
+}
+
+// configHash returns a hash of the serialized data of the managed resources.
+func configHash(resources ...map[string][]byte) string {
+	h := sha256.New()
+	for _, data := range resources {
+		keys := make([]string, 0, len(data))
+		for k := range data {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			h.Write([]byte(k))
+			h.Write(data[k])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,258 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/install"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/v1alpha1"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/validation"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestAuthnStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		grc            *config.GroupRoleBindingController
+		authnConfig    *authn.AuthnConfig
+		native         validation.NativeAuthentication
+		wantMode       v1alpha1.AuthenticationMode
+		wantIssuers    []string
+		wantComponents []string
+	}{
+		{
+			name:           "webhook only without group rolebinding controller configuration",
+			authnConfig:    &authn.AuthnConfig{Issuer: testIssuer},
+			wantMode:       v1alpha1.AuthenticationModeWebhook,
+			wantIssuers:    []string{testIssuer},
+			wantComponents: []string{"kube-jwt-authn-webhook", "group-rolebinding-controller"},
+		},
+		{
+			name:           "group rolebindings managed by the extension",
+			grc:            &config.GroupRoleBindingController{Mode: config.GroupRoleBindingControllerModeExtension},
+			authnConfig:    &authn.AuthnConfig{Issuer: testIssuer, Suspended: true},
+			wantMode:       v1alpha1.AuthenticationModeWebhook,
+			wantIssuers:    []string{testIssuer},
+			wantComponents: []string{"kube-jwt-authn-webhook"},
+		},
+		{
+			name:        "oidc config",
+			grc:         &config.GroupRoleBindingController{Mode: config.GroupRoleBindingControllerModeDeployment},
+			authnConfig: &authn.AuthnConfig{Issuer: testIssuer},
+			native: validation.NativeAuthentication{
+				OIDC: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To("https://oidc.example.com")},
+			},
+			wantMode:       v1alpha1.AuthenticationModeStructured,
+			wantIssuers:    []string{testIssuer, "https://oidc.example.com"},
+			wantComponents: []string{"kube-jwt-authn-webhook", "group-rolebinding-controller"},
+		},
+		{
+			name:        "structured authentication",
+			grc:         &config.GroupRoleBindingController{Mode: config.GroupRoleBindingControllerModeExtension},
+			authnConfig: &authn.AuthnConfig{Issuer: testIssuer},
+			native: validation.NativeAuthentication{
+				StructuredIssuers: []string{"https://one.example.com", testIssuer, "https://two.example.com"},
+			},
+			wantMode:       v1alpha1.AuthenticationModeStructured,
+			wantIssuers:    []string{testIssuer, "https://one.example.com", "https://two.example.com"},
+			wantComponents: []string{"kube-jwt-authn-webhook"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &actuator{config: config.ControllerConfiguration{GroupRoleBindingController: tt.grc}}
+			auth := &config.Auth{AuthenticatorImage: imagevector.ImageNameFitsAuthnWebhook}
+
+			status, err := a.authnStatus(tt.authnConfig, auth, tt.native, "tnnt", "default", testSeedNamespace, "config-hash", "input-hash")
+			if err != nil {
+				t.Fatalf("authnStatus() error = %v", err)
+			}
+
+			if status.Webhook.Mode != tt.wantMode {
+				t.Errorf("mode = %q, want %q", status.Webhook.Mode, tt.wantMode)
+			}
+			if !slices.Equal(status.Issuers, tt.wantIssuers) {
+				t.Errorf("issuers = %v, want %v", status.Issuers, tt.wantIssuers)
+			}
+			if status.Webhook.Suspended != tt.authnConfig.Suspended {
+				t.Errorf("suspended = %v, want %v", status.Webhook.Suspended, tt.authnConfig.Suspended)
+			}
+			if want := WebhookURL(testSeedNamespace, "authenticate"); status.Webhook.Endpoint != want {
+				t.Errorf("endpoint = %q, want %q", status.Webhook.Endpoint, want)
+			}
+			if status.Tenant != "tnnt" || status.Profile != "default" || status.ConfigHash != "config-hash" || status.InputHash != "input-hash" {
+				t.Errorf("status = %+v, want the given tenant, profile and hashes", status)
+			}
+
+			var components []string
+			for _, image := range status.Images {
+				components = append(components, image.Name)
+				if image.Image == "" || !strings.Contains(image.Image, "/") {
+					t.Errorf("image of %s = %q, want a resolved image reference", image.Name, image.Image)
+				}
+			}
+			if !slices.Equal(components, tt.wantComponents) {
+				t.Errorf("components = %v, want %v", components, tt.wantComponents)
+			}
+		})
+	}
+}
+
+func TestPinned(t *testing.T) {
+	tests := []struct {
+		image string
+		want  bool
+	}{
+		{image: "ghcr.io/fi-ts/gardener-extension-authn:v1.2.3", want: true},
+		{image: "ghcr.io/fi-ts/gardener-extension-authn@sha256:0123456789abcdef", want: true},
+		{image: "localhost:5001/gardener-extension-authn:v1.2.3", want: true},
+		{image: "ghcr.io/fi-ts/gardener-extension-authn:latest"},
+		{image: "localhost:5001/gardener-extension-authn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := pinned(tt.image); got != tt.want {
+				t.Errorf("pinned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnchanged(t *testing.T) {
+	const inputHash = "input-hash"
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, resourcesv1alpha1.AddToScheme, install.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	providerStatus, err := json.Marshal(&v1alpha1.AuthnStatus{
+		TypeMeta:  metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "AuthnStatus"},
+		InputHash: inputHash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// withSecret returns a managed resource whose data is stored in a secret of the same name
+	withSecret := func(mr *resourcesv1alpha1.ManagedResource) *resourcesv1alpha1.ManagedResource {
+		mr.Spec.SecretRefs = []corev1.LocalObjectReference{{Name: mr.Name}}
+		return mr
+	}
+	secret := func(name string) client.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testSeedNamespace}}
+	}
+
+	now := time.Now()
+	healthy := []client.Object{
+		withSecret(managedResource(v1alpha1.SeedAuthResourceName, true, now)),
+		withSecret(managedResource(v1alpha1.ShootAuthResourceName, true, now)),
+		secret(v1alpha1.SeedAuthResourceName),
+		secret(v1alpha1.ShootAuthResourceName),
+		secret(shootAccessSecretName()),
+	}
+
+	tests := []struct {
+		name      string
+		inputHash string
+		status    []byte
+		objects   []client.Object
+		want      bool
+	}{
+		{
+			name:      "unchanged",
+			inputHash: inputHash,
+			status:    providerStatus,
+			objects:   healthy,
+			want:      true,
+		},
+		{
+			name:      "changed inputs",
+			inputHash: "other",
+			status:    providerStatus,
+			objects:   healthy,
+		},
+		{
+			name:    "development build",
+			status:  providerStatus,
+			objects: healthy,
+		},
+		{
+			name:      "without provider status",
+			inputHash: inputHash,
+			objects:   healthy,
+		},
+		{
+			name:      "unhealthy managed resource",
+			inputHash: inputHash,
+			status:    providerStatus,
+			objects: []client.Object{
+				withSecret(managedResource(v1alpha1.SeedAuthResourceName, true, now)),
+				withSecret(managedResource(v1alpha1.ShootAuthResourceName, false, now)),
+				secret(v1alpha1.SeedAuthResourceName),
+				secret(v1alpha1.ShootAuthResourceName),
+				secret(shootAccessSecretName()),
+			},
+		},
+		{
+			name:      "missing managed resource",
+			inputHash: inputHash,
+			status:    providerStatus,
+			objects:   slices.Delete(slices.Clone(healthy), 1, 2),
+		},
+		{
+			name:      "missing secret of a managed resource",
+			inputHash: inputHash,
+			status:    providerStatus,
+			objects:   slices.Delete(slices.Clone(healthy), 3, 4),
+		},
+		{
+			name:      "missing shoot access secret",
+			inputHash: inputHash,
+			status:    providerStatus,
+			objects:   slices.Delete(slices.Clone(healthy), 4, 5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &actuator{
+				client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				decoder: serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+			}
+
+			ex := &extensionsv1alpha1.Extension{ObjectMeta: metav1.ObjectMeta{Name: "authn", Namespace: testSeedNamespace}}
+			if tt.status != nil {
+				ex.Status.ProviderStatus = &runtime.RawExtension{Raw: tt.status}
+			}
+
+			if got := a.unchanged(context.Background(), logr.Discard(), ex, &controller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}, tt.inputHash); got != tt.want {
+				t.Errorf("unchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
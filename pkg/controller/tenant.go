@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultTenantCacheTTL is the duration for which the tenant of a project is cached if the configuration does
+// not set one.
+const defaultTenantCacheTTL = 5 * time.Minute
+
+// tenantResolver determines the tenant of a shoot. The tenant annotation of the shoot takes precedence,
+// otherwise the tenant is read from the label and then from the annotation of the garden project.
+// The tenants of the projects are cached, expired entries are pruned whenever a tenant is cached and the
+// entry of a project is evicted when one of its shoots is deleted.
+type tenantResolver struct {
+	gardenReader client.Reader
+	config       *config.ProjectTenant
+
+	mu    sync.Mutex
+	cache map[string]cachedTenant
+}
+
+type cachedTenant struct {
+	tenant    string
+	fetchedAt time.Time
+}
+
+func newTenantResolver(gardenReader client.Reader, config *config.ProjectTenant) *tenantResolver {
+	return &tenantResolver{
+		gardenReader: gardenReader,
+		config:       config,
+		cache:        map[string]cachedTenant{},
+	}
+}
+
+func (r *tenantResolver) tenant(ctx context.Context, shoot *gardencorev1beta1.Shoot) (string, error) {
+	if tenant, ok := shoot.Annotations[tag.ClusterTenant]; ok {
+		return tenant, nil
+	}
+
+	if r.config == nil || r.gardenReader == nil {
+		return "", fmt.Errorf("cluster has no tenant annotation")
+	}
+
+	// the lock is not held while the project is read, such that a slow garden does not block the cached lookups
+	r.mu.Lock()
+	cached, ok := r.cache[shoot.Namespace]
+	r.mu.Unlock()
+	if ok && !r.expired(cached) {
+		return cached.tenant, nil
+	}
+
+	project, err := gutil.ProjectForNamespaceFromReader(ctx, r.gardenReader, shoot.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("cluster has no tenant annotation and project of namespace %s could not be read: %w", shoot.Namespace, err)
+	}
+
+	tenant := ""
+	if r.config.Label != "" {
+		tenant = project.Labels[r.config.Label]
+	}
+	if tenant == "" && r.config.Annotation != "" {
+		tenant = project.Annotations[r.config.Annotation]
+	}
+	if tenant == "" {
+		return "", fmt.Errorf("neither cluster nor project %s contain a tenant", project.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for namespace, cached := range r.cache {
+		if r.expired(cached) {
+			delete(r.cache, namespace)
+		}
+	}
+	r.cache[shoot.Namespace] = cachedTenant{
+		tenant:    tenant,
+		fetchedAt: time.Now(),
+	}
+
+	return tenant, nil
+}
+
+// evict removes the cached tenant of the given garden namespace.
+func (r *tenantResolver) evict(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.cache, namespace)
+}
+
+func (r *tenantResolver) expired(cached cachedTenant) bool {
+	ttl := defaultTenantCacheTTL
+	if r.config.CacheTTL != nil {
+		ttl = r.config.CacheTTL.Duration
+	}
+	return time.Since(cached.fetchedAt) >= ttl
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/metal-stack/metal-lib/pkg/tag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newGardenClient(t *testing.T, projects ...*gardencorev1beta1.Project) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := gardencorev1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&gardencorev1beta1.Project{}, core.ProjectNamespace, func(obj client.Object) []string {
+		return []string{ptr.Deref(obj.(*gardencorev1beta1.Project).Spec.Namespace, "")}
+	})
+	for _, p := range projects {
+		builder = builder.WithObjects(p)
+	}
+	return builder.Build()
+}
+
+func project(name, tenant string) *gardencorev1beta1.Project {
+	return &gardencorev1beta1.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tenant": tenant}},
+		Spec:       gardencorev1beta1.ProjectSpec{Namespace: ptr.To("garden-" + name)},
+	}
+}
+
+func shootIn(namespace string, annotations map[string]string) *gardencorev1beta1.Shoot {
+	return &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: namespace, Annotations: annotations}}
+}
+
+func TestTenantResolver(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		config  *config.ProjectTenant
+		shoot   *gardencorev1beta1.Shoot
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "tenant annotation of the shoot",
+			config: &config.ProjectTenant{Label: "tenant"},
+			shoot:  shootIn("garden-a", map[string]string{tag.ClusterTenant: "shoot-tenant"}),
+			want:   "shoot-tenant",
+		},
+		{
+			name:   "label of the project",
+			config: &config.ProjectTenant{Label: "tenant"},
+			shoot:  shootIn("garden-a", nil),
+			want:   "tnnt",
+		},
+		{
+			name:    "no project tenant configured",
+			shoot:   shootIn("garden-a", nil),
+			wantErr: true,
+		},
+		{
+			name:    "unknown project",
+			config:  &config.ProjectTenant{Label: "tenant"},
+			shoot:   shootIn("garden-unknown", nil),
+			wantErr: true,
+		},
+		{
+			name:    "project without tenant",
+			config:  &config.ProjectTenant{Label: "other"},
+			shoot:   shootIn("garden-a", nil),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTenantResolver(newGardenClient(t, project("a", "tnnt")), tt.config)
+
+			got, err := r.tenant(ctx, tt.shoot)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tenant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tenant() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantResolverCache(t *testing.T) {
+	ctx := context.Background()
+
+	c := newGardenClient(t, project("a", "tnnt"), project("b", "other"))
+	r := newTenantResolver(c, &config.ProjectTenant{Label: "tenant", CacheTTL: &metav1.Duration{Duration: time.Hour}})
+
+	relabel := func(t *testing.T, name, tenant string) {
+		t.Helper()
+		p := &gardencorev1beta1.Project{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, p); err != nil {
+			t.Fatal(err)
+		}
+		p.Labels["tenant"] = tenant
+		if err := c.Update(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertTenant := func(t *testing.T, namespace, want string) {
+		t.Helper()
+		got, err := r.tenant(ctx, shootIn(namespace, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("tenant() = %q, want %q", got, want)
+		}
+	}
+
+	assertTenant(t, "garden-a", "tnnt")
+	relabel(t, "a", "changed")
+	assertTenant(t, "garden-a", "tnnt")
+
+	r.evict("garden-a")
+	assertTenant(t, "garden-a", "changed")
+
+	// expire the cached entry of project a, it is pruned when the tenant of another project is cached
+	r.cache["garden-a"] = cachedTenant{tenant: "changed", fetchedAt: time.Now().Add(-2 * time.Hour)}
+	assertTenant(t, "garden-b", "other")
+	if _, ok := r.cache["garden-a"]; ok {
+		t.Error("expired entry was not pruned")
+	}
+}
+
+func TestTenantResolverDoesNotBlockCachedLookups(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		listing = make(chan struct{})
+		release = make(chan struct{})
+	)
+	c := interceptor.NewClient(newGardenClient(t, project("a", "tnnt"), project("b", "other")).(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			close(listing)
+			<-release
+			return c.List(ctx, list, opts...)
+		},
+	})
+	r := newTenantResolver(c, &config.ProjectTenant{Label: "tenant"})
+	r.cache["garden-a"] = cachedTenant{tenant: "tnnt", fetchedAt: time.Now()}
+
+	done := make(chan error)
+	go func() {
+		_, err := r.tenant(ctx, shootIn("garden-b", nil))
+		done <- err
+	}()
+	<-listing
+
+	// the project of b is still being read
+	got, err := r.tenant(ctx, shootIn("garden-a", nil))
+	if err != nil || got != "tnnt" {
+		t.Errorf("tenant() = %q, %v, want the cached tenant", got, err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
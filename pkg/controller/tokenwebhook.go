@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+)
+
+// defaultTokenWebhookTimeout is the timeout of the token webhook if neither the controller configuration nor the
+// provider config set one.
+const defaultTokenWebhookTimeout = 10 * time.Second
+
+// TokenWebhookSettings are the effective settings of the token webhook of a shoot.
+type TokenWebhookSettings struct {
+	// CacheTTL is the duration for which the kube-apiserver caches the responses of the token webhook.
+	CacheTTL time.Duration
+	// Timeout is the timeout for authenticating a token.
+	Timeout time.Duration
+	// RetryInitialDelay is the delay before the first retry of a failed membership backend request.
+	RetryInitialDelay time.Duration
+	// Retries is the maximum number of retries of a failed membership backend request.
+	Retries int32
+}
+
+// TokenWebhookSettingsFor returns the token webhook settings of a shoot. The settings of the provider config
+// take precedence over the defaults of the controller configuration.
+func TokenWebhookSettingsFor(defaults *config.TokenWebhook, override *authn.TokenWebhook) TokenWebhookSettings {
+	settings := TokenWebhookSettings{
+		Timeout: defaultTokenWebhookTimeout,
+	}
+
+	if defaults != nil {
+		if defaults.CacheTTL != nil {
+			settings.CacheTTL = defaults.CacheTTL.Duration
+		}
+		if defaults.Timeout != nil {
+			settings.Timeout = defaults.Timeout.Duration
+		}
+		if rb := defaults.RetryBackoff; rb != nil {
+			if rb.InitialDelay != nil {
+				settings.RetryInitialDelay = rb.InitialDelay.Duration
+			}
+			if rb.Retries != nil {
+				settings.Retries = *rb.Retries
+			}
+		}
+	}
+
+	if override != nil {
+		if override.CacheTTL != nil {
+			settings.CacheTTL = override.CacheTTL.Duration
+		}
+		if override.Timeout != nil {
+			settings.Timeout = override.Timeout.Duration
+		}
+		if rb := override.RetryBackoff; rb != nil {
+			if rb.InitialDelay != nil {
+				settings.RetryInitialDelay = rb.InitialDelay.Duration
+			}
+			if rb.Retries != nil {
+				settings.Retries = *rb.Retries
+			}
+		}
+	}
+
+	return settings
+}
+
+// WebhookURL returns the url of the given path of the token webhook in the seed namespace of a shoot.
+func WebhookURL(namespace, path string) string {
+	return fmt.Sprintf("http://kube-jwt-authn-webhook.%s.svc.cluster.local:8443/%s", namespace, path)
+}
@@ -0,0 +1,58 @@
+package grouprolebinding
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Reconciler reconciles the group rolebindings of a namespace.
+type Reconciler struct {
+	Client  client.Client
+	Options Options
+}
+
+// Reconcile ensures the group rolebindings of the requested namespace.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: req.Name}, ns); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if ns.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{}, EnsureNamespace(ctx, r.Client, r.Options, ns.Name)
+}
+
+// AddToManager adds the group rolebinding controller to the given manager. It watches namespaces
+// and the rolebindings managed by this controller, such that manual changes get reverted.
+func AddToManager(mgr manager.Manager, opts Options) error {
+	r := &Reconciler{
+		Client:  mgr.GetClient(),
+		Options: opts,
+	}
+
+	return builder.ControllerManagedBy(mgr).
+		Named(Name).
+		For(&corev1.Namespace{}).
+		Watches(
+			&rbacv1.RoleBinding{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: obj.GetNamespace()}}}
+			}),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetLabels()[LabelManagedBy] == Name
+			})),
+		).
+		Complete(r)
+}
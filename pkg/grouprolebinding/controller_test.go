@@ -0,0 +1,124 @@
+package grouprolebinding
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestReconciler(t *testing.T) {
+	opts := Options{
+		ClusterName:       "shoot",
+		ExcludeNamespaces: DefaultExcludeNamespaces,
+		ExpectedGroups:    []string{"edit"},
+		AdditionalTenants: []TenantRoles{
+			{Name: "partner", RoleMapping: map[string]string{"viewer": "view"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		existing  []client.Object
+		want      map[string][2]string
+	}{
+		{
+			name:      "new namespace",
+			namespace: "team",
+			existing:  []client.Object{namespace("team")},
+			want: map[string][2]string{
+				"group-rolebinding-controller:edit":           {"shoot-team-edit", "edit"},
+				"group-rolebinding-controller:partner:viewer": {"partner:shoot-team-viewer", "view"},
+			},
+		},
+		{
+			name:      "excluded namespace",
+			namespace: "default",
+			existing:  []client.Object{namespace("default")},
+			want:      map[string][2]string{},
+		},
+		{
+			name:      "deleted namespace",
+			namespace: "gone",
+			want:      map[string][2]string{},
+		},
+		{
+			name:      "terminating namespace",
+			namespace: "leaving",
+			existing: []client.Object{
+				terminatingNamespace("leaving"),
+				managedRoleBinding("leaving", "group-rolebinding-controller:admin", "admin"),
+			},
+			want: map[string][2]string{
+				"group-rolebinding-controller:admin": {"", "admin"},
+			},
+		},
+		{
+			name:      "stale rolebinding",
+			namespace: "team",
+			existing: []client.Object{
+				namespace("team"),
+				managedRoleBinding("team", "group-rolebinding-controller:admin", "admin"),
+			},
+			want: map[string][2]string{
+				"group-rolebinding-controller:edit":           {"shoot-team-edit", "edit"},
+				"group-rolebinding-controller:partner:viewer": {"partner:shoot-team-viewer", "view"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.existing...).Build()
+			r := &Reconciler{Client: c, Options: opts}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKey{Name: tt.namespace}})
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if !result.IsZero() {
+				t.Errorf("Reconcile() result = %v, want empty result", result)
+			}
+
+			if got := roleBindings(t, c, tt.namespace); !equalRoleBindings(got, tt.want) {
+				t.Errorf("rolebindings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcilerSyncEquivalence(t *testing.T) {
+	// the controller deployed per shoot and the in-process sync of the extension must produce the same rolebindings
+	opts := Options{
+		ClusterName:    "shoot",
+		ExpectedGroups: DefaultExpectedGroups,
+	}
+
+	perShoot := fake.NewClientBuilder().WithObjects(namespace("team")).Build()
+	if _, err := (&Reconciler{Client: perShoot, Options: opts}).Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKey{Name: "team"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	inProcess := fake.NewClientBuilder().WithObjects(namespace("team")).Build()
+	if err := Sync(context.Background(), inProcess, opts); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	got, want := roleBindings(t, perShoot, "team"), roleBindings(t, inProcess, "team")
+	if len(got) != len(DefaultExpectedGroups) || !equalRoleBindings(got, want) {
+		t.Errorf("per shoot rolebindings = %v, in-process rolebindings = %v", got, want)
+	}
+
+	list := &rbacv1.RoleBindingList{}
+	if err := perShoot.List(context.Background(), list, client.MatchingLabels{LabelManagedBy: Name}); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != len(DefaultExpectedGroups) {
+		t.Errorf("got %d managed rolebindings, want %d", len(list.Items), len(DefaultExpectedGroups))
+	}
+}
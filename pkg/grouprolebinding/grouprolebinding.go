@@ -0,0 +1,234 @@
+package grouprolebinding
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Name is the name of the group rolebinding controller.
+	Name = "group-rolebinding-controller"
+	// LabelManagedBy is the label that marks rolebindings created by this controller.
+	LabelManagedBy = "app.kubernetes.io/managed-by"
+	// LabelRole is the label that holds the cluster role a rolebinding refers to.
+	LabelRole = "authn.fits.cloud/role"
+	// LabelTenant is the label that holds the additional tenant a rolebinding was created for.
+	LabelTenant = "authn.fits.cloud/tenant"
+)
+
+var (
+	// DefaultExcludeNamespaces are the namespaces in which no rolebindings are created by default.
+	DefaultExcludeNamespaces = []string{"kube-system", "kube-public", "kube-node-lease", "default"}
+	// DefaultExpectedGroups are the cluster roles that are bound in every namespace by default.
+	DefaultExpectedGroups = []string{"admin", "edit", "view"}
+)
+
+// Options contains the settings for the group rolebinding reconciliation.
+type Options struct {
+	// ClusterName is the name of the cluster, it is part of every group name.
+	ClusterName string
+	// ExcludeNamespaces are the namespaces in which no rolebindings are created.
+	ExcludeNamespaces []string
+	// ExpectedGroups are the cluster roles that are bound to a group in every namespace.
+	ExpectedGroups []string
+	// AdditionalTenants are tenants besides the owning tenant whose members may access the cluster.
+	AdditionalTenants []TenantRoles
+}
+
+// TenantRoles defines the roles an additional tenant gets in every namespace.
+type TenantRoles struct {
+	// Name is the name of the tenant.
+	Name string
+	// RoleMapping maps the roles of the tenant's groups to the cluster roles that are bound.
+	RoleMapping map[string]string
+}
+
+// String returns the tenant roles in the format "<tenant>=<role>:<clusterrole>,...", which is understood by ParseTenantRoles.
+func (t TenantRoles) String() string {
+	var mappings []string
+	for _, role := range slices.Sorted(maps.Keys(t.RoleMapping)) {
+		mappings = append(mappings, role+":"+t.RoleMapping[role])
+	}
+	return t.Name + "=" + strings.Join(mappings, ",")
+}
+
+// ParseTenantRoles parses tenant roles in the format "<tenant>=<role>:<clusterrole>,...".
+func ParseTenantRoles(s string) (TenantRoles, error) {
+	name, mappings, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return TenantRoles{}, fmt.Errorf("invalid tenant roles %q, expected <tenant>=<role>:<clusterrole>,...", s)
+	}
+
+	t := TenantRoles{
+		Name:        name,
+		RoleMapping: map[string]string{},
+	}
+
+	for _, mapping := range strings.Split(mappings, ",") {
+		role, clusterRole, ok := strings.Cut(mapping, ":")
+		if !ok || role == "" || clusterRole == "" {
+			return TenantRoles{}, fmt.Errorf("invalid role mapping %q of tenant %s, expected <role>:<clusterrole>", mapping, name)
+		}
+		t.RoleMapping[role] = clusterRole
+	}
+
+	return t, nil
+}
+
+// GroupName returns the name of the group that is granted the given role in the given namespace.
+func GroupName(clusterName, namespace, role string) string {
+	return fmt.Sprintf("%s-%s-%s", clusterName, namespace, role)
+}
+
+// TenantGroupName returns the name of the group that is granted the given role in the given namespace
+// for members of an additional tenant. The tenant prefix prevents tenants from granting each other access.
+func TenantGroupName(tenant, clusterName, namespace, role string) string {
+	return fmt.Sprintf("%s:%s", tenant, GroupName(clusterName, namespace, role))
+}
+
+// RoleBindingName returns the name of the rolebinding that grants the given role.
+func RoleBindingName(role string) string {
+	return fmt.Sprintf("%s:%s", Name, role)
+}
+
+// TenantRoleBindingName returns the name of the rolebinding that grants the given role to an additional tenant.
+func TenantRoleBindingName(tenant, role string) string {
+	return fmt.Sprintf("%s:%s:%s", Name, tenant, role)
+}
+
+// RoleBindings returns the rolebindings that are expected in the given namespace.
+func RoleBindings(opts Options, namespace string) []*rbacv1.RoleBinding {
+	var rbs []*rbacv1.RoleBinding
+
+	for _, role := range opts.ExpectedGroups {
+		rbs = append(rbs, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      RoleBindingName(role),
+				Namespace: namespace,
+				Labels: map[string]string{
+					LabelManagedBy: Name,
+					LabelRole:      role,
+				},
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Kind:     rbacv1.GroupKind,
+					Name:     GroupName(opts.ClusterName, namespace, role),
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     role,
+			},
+		})
+	}
+
+	for _, tenant := range opts.AdditionalTenants {
+		roles := slices.Sorted(maps.Keys(tenant.RoleMapping))
+
+		for _, role := range roles {
+			rbs = append(rbs, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      TenantRoleBindingName(tenant.Name, role),
+					Namespace: namespace,
+					Labels: map[string]string{
+						LabelManagedBy: Name,
+						LabelRole:      tenant.RoleMapping[role],
+						LabelTenant:    tenant.Name,
+					},
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						APIGroup: rbacv1.GroupName,
+						Kind:     rbacv1.GroupKind,
+						Name:     TenantGroupName(tenant.Name, opts.ClusterName, namespace, role),
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     tenant.RoleMapping[role],
+				},
+			})
+		}
+	}
+
+	return rbs
+}
+
+// EnsureNamespace creates or updates the expected rolebindings in the given namespace
+// and removes rolebindings of this controller that are not expected anymore.
+func EnsureNamespace(ctx context.Context, c client.Client, opts Options, namespace string) error {
+	if slices.Contains(opts.ExcludeNamespaces, namespace) {
+		return nil
+	}
+
+	expected := RoleBindings(opts, namespace)
+
+	for _, rb := range expected {
+		desired := rb.DeepCopy()
+		if _, err := controllerutils.GetAndCreateOrMergePatch(ctx, c, rb, func() error {
+			rb.Labels = desired.Labels
+			rb.Subjects = desired.Subjects
+			rb.RoleRef = desired.RoleRef
+			return nil
+		}); err != nil {
+			return fmt.Errorf("unable to ensure rolebinding %s/%s: %w", namespace, desired.Name, err)
+		}
+	}
+
+	existing := &rbacv1.RoleBindingList{}
+	if err := c.List(ctx, existing, client.InNamespace(namespace), client.MatchingLabels{LabelManagedBy: Name}); err != nil {
+		return err
+	}
+
+	for i := range existing.Items {
+		rb := &existing.Items[i]
+		if slices.ContainsFunc(expected, func(e *rbacv1.RoleBinding) bool { return e.Name == rb.Name }) {
+			continue
+		}
+
+		if err := c.Delete(ctx, rb); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete stale rolebinding %s/%s: %w", namespace, rb.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Sync ensures the rolebindings in all namespaces of the cluster.
+func Sync(ctx context.Context, c client.Client, opts Options) error {
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces); err != nil {
+		return err
+	}
+
+	for _, ns := range namespaces.Items {
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		if err := EnsureNamespace(ctx, c, opts, ns.Name); err != nil {
+			if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+				// namespace was deleted in the meantime or is terminating
+				continue
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package grouprolebinding
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func terminatingNamespace(name string) *corev1.Namespace {
+	ns := namespace(name)
+	ns.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	ns.Finalizers = []string{"kubernetes"}
+	return ns
+}
+
+func managedRoleBinding(namespace, name, role string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				LabelManagedBy: Name,
+				LabelRole:      role,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     role,
+		},
+	}
+}
+
+// roleBindings returns the rolebindings in the given namespace as a map of name to the subject and the cluster role.
+func roleBindings(t *testing.T, c client.Client, namespace string) map[string][2]string {
+	t.Helper()
+
+	list := &rbacv1.RoleBindingList{}
+	if err := c.List(context.Background(), list, client.InNamespace(namespace)); err != nil {
+		t.Fatalf("unable to list rolebindings: %v", err)
+	}
+
+	rbs := map[string][2]string{}
+	for _, rb := range list.Items {
+		subject := ""
+		if len(rb.Subjects) > 0 {
+			subject = rb.Subjects[0].Name
+		}
+		rbs[rb.Name] = [2]string{subject, rb.RoleRef.Name}
+	}
+	return rbs
+}
+
+func TestRoleBindings(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want map[string][2]string
+	}{
+		{
+			name: "no groups",
+			opts: Options{ClusterName: "shoot"},
+			want: map[string][2]string{},
+		},
+		{
+			name: "expected groups",
+			opts: Options{ClusterName: "shoot", ExpectedGroups: []string{"admin", "view"}},
+			want: map[string][2]string{
+				"group-rolebinding-controller:admin": {"shoot-team-admin", "admin"},
+				"group-rolebinding-controller:view":  {"shoot-team-view", "view"},
+			},
+		},
+		{
+			name: "additional tenants",
+			opts: Options{
+				ClusterName:    "shoot",
+				ExpectedGroups: []string{"edit"},
+				AdditionalTenants: []TenantRoles{
+					{Name: "partner", RoleMapping: map[string]string{"admin": "edit", "viewer": "view"}},
+				},
+			},
+			want: map[string][2]string{
+				"group-rolebinding-controller:edit":           {"shoot-team-edit", "edit"},
+				"group-rolebinding-controller:partner:admin":  {"partner:shoot-team-admin", "edit"},
+				"group-rolebinding-controller:partner:viewer": {"partner:shoot-team-viewer", "view"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string][2]string{}
+			for _, rb := range RoleBindings(tt.opts, "team") {
+				if rb.Namespace != "team" {
+					t.Errorf("rolebinding %s is in namespace %s, want team", rb.Name, rb.Namespace)
+				}
+				if rb.Labels[LabelManagedBy] != Name {
+					t.Errorf("rolebinding %s is not labeled as managed by %s", rb.Name, Name)
+				}
+				got[rb.Name] = [2]string{rb.Subjects[0].Name, rb.RoleRef.Name}
+			}
+
+			if !equalRoleBindings(got, tt.want) {
+				t.Errorf("RoleBindings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureNamespace(t *testing.T) {
+	opts := Options{
+		ClusterName:       "shoot",
+		ExcludeNamespaces: []string{"kube-system"},
+		ExpectedGroups:    []string{"admin", "view"},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		existing  []client.Object
+		want      map[string][2]string
+	}{
+		{
+			name:      "creates expected rolebindings",
+			namespace: "team",
+			want: map[string][2]string{
+				"group-rolebinding-controller:admin": {"shoot-team-admin", "admin"},
+				"group-rolebinding-controller:view":  {"shoot-team-view", "view"},
+			},
+		},
+		{
+			name:      "skips excluded namespaces",
+			namespace: "kube-system",
+			want:      map[string][2]string{},
+		},
+		{
+			name:      "reverts modified rolebindings",
+			namespace: "team",
+			existing: []client.Object{
+				managedRoleBinding("team", "group-rolebinding-controller:admin", "cluster-admin"),
+			},
+			want: map[string][2]string{
+				"group-rolebinding-controller:admin": {"shoot-team-admin", "admin"},
+				"group-rolebinding-controller:view":  {"shoot-team-view", "view"},
+			},
+		},
+		{
+			name:      "prunes stale rolebindings",
+			namespace: "team",
+			existing: []client.Object{
+				managedRoleBinding("team", "group-rolebinding-controller:edit", "edit"),
+				managedRoleBinding("team", "group-rolebinding-controller:partner:admin", "admin"),
+			},
+			want: map[string][2]string{
+				"group-rolebinding-controller:admin": {"shoot-team-admin", "admin"},
+				"group-rolebinding-controller:view":  {"shoot-team-view", "view"},
+			},
+		},
+		{
+			name:      "keeps foreign rolebindings",
+			namespace: "team",
+			existing: []client.Object{
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "team"},
+					RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+				},
+			},
+			want: map[string][2]string{
+				"foreign":                            {"", "edit"},
+				"group-rolebinding-controller:admin": {"shoot-team-admin", "admin"},
+				"group-rolebinding-controller:view":  {"shoot-team-view", "view"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(append(tt.existing, namespace(tt.namespace))...).Build()
+
+			if err := EnsureNamespace(context.Background(), c, opts, tt.namespace); err != nil {
+				t.Fatalf("EnsureNamespace() error = %v", err)
+			}
+
+			if got := roleBindings(t, c, tt.namespace); !equalRoleBindings(got, tt.want) {
+				t.Errorf("rolebindings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	opts := Options{
+		ClusterName:       "shoot",
+		ExcludeNamespaces: DefaultExcludeNamespaces,
+		ExpectedGroups:    []string{"view"},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(
+		namespace("kube-system"),
+		namespace("team-a"),
+		namespace("team-b"),
+		terminatingNamespace("leaving"),
+		managedRoleBinding("team-b", "group-rolebinding-controller:admin", "admin"),
+	).Build()
+
+	if err := Sync(context.Background(), c, opts); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	want := map[string]map[string][2]string{
+		"kube-system": {},
+		"leaving":     {},
+		"team-a":      {"group-rolebinding-controller:view": {"shoot-team-a-view", "view"}},
+		"team-b":      {"group-rolebinding-controller:view": {"shoot-team-b-view", "view"}},
+	}
+	for ns, rbs := range want {
+		if got := roleBindings(t, c, ns); !equalRoleBindings(got, rbs) {
+			t.Errorf("rolebindings in namespace %s = %v, want %v", ns, got, rbs)
+		}
+	}
+}
+
+func TestParseTenantRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    TenantRoles
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			in:   "partner=admin:edit,viewer:view",
+			want: TenantRoles{Name: "partner", RoleMapping: map[string]string{"admin": "edit", "viewer": "view"}},
+		},
+		{name: "missing tenant", in: "=admin:edit", wantErr: true},
+		{name: "missing mapping", in: "partner", wantErr: true},
+		{name: "invalid mapping", in: "partner=admin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTenantRoles(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTenantRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("ParseTenantRoles() = %s, want %s", got, tt.want)
+			}
+			if got.String() != tt.in {
+				t.Errorf("String() = %s, want %s", got, tt.in)
+			}
+		})
+	}
+}
+
+func equalRoleBindings(a, b map[string][2]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || !slices.Equal(v[:], w[:]) {
+			return false
+		}
+	}
+	return true
+}
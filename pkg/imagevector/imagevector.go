@@ -7,6 +7,15 @@ import (
 	"github.com/fi-ts/gardener-extension-authn/charts"
 )
 
+const (
+	// ImageNameAuthnWebhook is the name of the kubernetes-authn-webhook image.
+	ImageNameAuthnWebhook = "authn-webhook"
+	// ImageNameFitsAuthnWebhook is the name of the token review authenticator image built from this repository.
+	ImageNameFitsAuthnWebhook = "fits-authn-webhook"
+	// ImageNameGroupRoleBindingController is the name of the group rolebinding controller image.
+	ImageNameGroupRoleBindingController = "group-rolebinding-controller"
+)
+
 var imageVector imagevector.ImageVector
 
 func init() {
@@ -0,0 +1,82 @@
+package subjectaccessreview
+
+import (
+	"slices"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// ExtraTenant is the key of the user extra that holds the tenant of a user authenticated by the token webhook.
+const ExtraTenant = "tenant"
+
+// Rule matches resource requests.
+type Rule struct {
+	// Verbs are the verbs of the requests, "*" matches all verbs.
+	Verbs []string `json:"verbs"`
+	// Resources are the resources of the requests including the subresource, e.g. "pods/exec". "*" matches all resources.
+	Resources []string `json:"resources"`
+}
+
+// Matches returns true if the rule matches the given resource request.
+func (r Rule) Matches(attrs *authorizationv1.ResourceAttributes) bool {
+	resource := attrs.Resource
+	if attrs.Subresource != "" {
+		resource = resource + "/" + attrs.Subresource
+	}
+
+	return matches(r.Verbs, attrs.Verb) && matches(r.Resources, resource)
+}
+
+func matches(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return v == "*" || v == value })
+}
+
+// Config contains the settings of the authorizer.
+type Config struct {
+	// ProviderTenant is the tenant of the provider.
+	ProviderTenant string
+	// ProviderTenantDeny are the requests that are denied for members of the provider tenant.
+	ProviderTenantDeny []Rule
+}
+
+// Decision is the result of an authorization.
+type Decision int
+
+const (
+	// DecisionNoOpinion leaves the decision to the next authorizer of the chain.
+	DecisionNoOpinion Decision = iota
+	// DecisionDeny denies the request.
+	DecisionDeny
+)
+
+// Authorizer decides about requests based on the tenant of the user. It never allows a request on its own,
+// such that permissions are still granted by RBAC only.
+type Authorizer struct {
+	config Config
+}
+
+// NewAuthorizer returns a new authorizer.
+func NewAuthorizer(config Config) *Authorizer {
+	return &Authorizer{config: config}
+}
+
+// Authorize returns the decision and the reason for the given subject access review.
+func (a *Authorizer) Authorize(spec authorizationv1.SubjectAccessReviewSpec) (Decision, string) {
+	if spec.ResourceAttributes == nil {
+		return DecisionNoOpinion, ""
+	}
+
+	tenants := spec.Extra[ExtraTenant]
+	if a.config.ProviderTenant == "" || !slices.ContainsFunc(tenants, func(t string) bool { return strings.EqualFold(t, a.config.ProviderTenant) }) {
+		return DecisionNoOpinion, ""
+	}
+
+	for _, rule := range a.config.ProviderTenantDeny {
+		if rule.Matches(spec.ResourceAttributes) {
+			return DecisionDeny, "request is not allowed for members of the provider tenant"
+		}
+	}
+
+	return DecisionNoOpinion, ""
+}
@@ -0,0 +1,100 @@
+package subjectaccessreview
+
+import (
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+func TestAuthorize(t *testing.T) {
+	a := NewAuthorizer(Config{
+		ProviderTenant: "prvdr",
+		ProviderTenantDeny: []Rule{
+			{Verbs: []string{"*"}, Resources: []string{"pods/exec", "pods/portforward"}},
+			{Verbs: []string{"get", "list"}, Resources: []string{"secrets"}},
+		},
+	})
+
+	review := func(tenant string, attrs *authorizationv1.ResourceAttributes) authorizationv1.SubjectAccessReviewSpec {
+		spec := authorizationv1.SubjectAccessReviewSpec{User: "user", ResourceAttributes: attrs}
+		if tenant != "" {
+			spec.Extra = map[string]authorizationv1.ExtraValue{ExtraTenant: {tenant}}
+		}
+		return spec
+	}
+
+	tests := []struct {
+		name string
+		spec authorizationv1.SubjectAccessReviewSpec
+		want Decision
+	}{
+		{
+			name: "exec of the provider tenant",
+			spec: review("prvdr", &authorizationv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec"}),
+			want: DecisionDeny,
+		},
+		{
+			name: "provider tenant in different case",
+			spec: review("PRVDR", &authorizationv1.ResourceAttributes{Verb: "get", Resource: "pods", Subresource: "portforward"}),
+			want: DecisionDeny,
+		},
+		{
+			name: "matching verb of the provider tenant",
+			spec: review("prvdr", &authorizationv1.ResourceAttributes{Verb: "list", Resource: "secrets"}),
+			want: DecisionDeny,
+		},
+		{
+			name: "other verb of the provider tenant",
+			spec: review("prvdr", &authorizationv1.ResourceAttributes{Verb: "delete", Resource: "secrets"}),
+			want: DecisionNoOpinion,
+		},
+		{
+			name: "pod without subresource of the provider tenant",
+			spec: review("prvdr", &authorizationv1.ResourceAttributes{Verb: "get", Resource: "pods"}),
+			want: DecisionNoOpinion,
+		},
+		{
+			name: "exec of the owning tenant",
+			spec: review("tnnt", &authorizationv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec"}),
+			want: DecisionNoOpinion,
+		},
+		{
+			name: "user without tenant",
+			spec: review("", &authorizationv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec"}),
+			want: DecisionNoOpinion,
+		},
+		{
+			name: "non resource request of the provider tenant",
+			spec: authorizationv1.SubjectAccessReviewSpec{
+				User:                  "user",
+				Extra:                 map[string]authorizationv1.ExtraValue{ExtraTenant: {"prvdr"}},
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: "/healthz", Verb: "get"},
+			},
+			want: DecisionNoOpinion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := a.Authorize(tt.spec)
+			if got != tt.want {
+				t.Errorf("Authorize() = %v, want %v", got, tt.want)
+			}
+			if (reason != "") != (tt.want == DecisionDeny) {
+				t.Errorf("Authorize() reason = %q", reason)
+			}
+		})
+	}
+}
+
+func TestAuthorizeWithoutProviderTenant(t *testing.T) {
+	a := NewAuthorizer(Config{ProviderTenantDeny: []Rule{{Verbs: []string{"*"}, Resources: []string{"*"}}}})
+
+	got, _ := a.Authorize(authorizationv1.SubjectAccessReviewSpec{
+		Extra:              map[string]authorizationv1.ExtraValue{ExtraTenant: {""}},
+		ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "get", Resource: "pods"},
+	})
+	if got != DecisionNoOpinion {
+		t.Errorf("Authorize() = %v, want %v", got, DecisionNoOpinion)
+	}
+}
@@ -0,0 +1,92 @@
+package subjectaccessreview
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+var (
+	subjectAccessReviews = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authn_webhook_subjectaccessreviews_total",
+		Help: "Number of processed subject access reviews by decision.",
+	}, []string{"decision"})
+
+	subjectAccessReviewDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "authn_webhook_subjectaccessreview_duration_seconds",
+		Help:    "Duration of subject access reviews.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+const (
+	decisionDenied    = "denied"
+	decisionNoOpinion = "no_opinion"
+	decisionInvalid   = "invalid"
+)
+
+// RegisterMetrics registers the metrics of the subject access review handler at the given registerer.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{subjectAccessReviews, subjectAccessReviewDuration} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves subject access reviews of the kube-apiserver.
+type Handler struct {
+	log        logr.Logger
+	authorizer *Authorizer
+}
+
+// NewHandler returns a new subject access review handler.
+func NewHandler(log logr.Logger, authorizer *Authorizer) *Handler {
+	return &Handler{
+		log:        log,
+		authorizer: authorizer,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	defer func() {
+		subjectAccessReviewDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	review := &authorizationv1.SubjectAccessReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		subjectAccessReviews.WithLabelValues(decisionInvalid).Inc()
+		http.Error(w, "unable to decode subject access review", http.StatusBadRequest)
+		return
+	}
+
+	review.Status = authorizationv1.SubjectAccessReviewStatus{}
+
+	switch decision, reason := h.authorizer.Authorize(review.Spec); decision {
+	case DecisionDeny:
+		subjectAccessReviews.WithLabelValues(decisionDenied).Inc()
+		h.log.Info("request denied", "user", review.Spec.User, "attributes", review.Spec.ResourceAttributes)
+		review.Status.Denied = true
+		review.Status.Reason = reason
+	default:
+		subjectAccessReviews.WithLabelValues(decisionNoOpinion).Inc()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.Error(err, "unable to encode subject access review response")
+	}
+}
@@ -0,0 +1,98 @@
+package subjectaccessreview
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(logr.Discard(), NewAuthorizer(Config{
+		ProviderTenant:     "prvdr",
+		ProviderTenantDeny: []Rule{{Verbs: []string{"*"}, Resources: []string{"pods/exec"}}},
+	}))
+
+	review := func(tenant string) []byte {
+		data, err := json.Marshal(&authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:               "user",
+				Extra:              map[string]authorizationv1.ExtraValue{ExtraTenant: {tenant}},
+				ResourceAttributes: &authorizationv1.ResourceAttributes{Verb: "create", Resource: "pods", Subresource: "exec"},
+			},
+			// the status of the request must not be returned
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		body       []byte
+		wantStatus int
+		wantDenied bool
+	}{
+		{
+			name:       "denied",
+			method:     http.MethodPost,
+			body:       review("prvdr"),
+			wantStatus: http.StatusOK,
+			wantDenied: true,
+		},
+		{
+			name:       "no opinion",
+			method:     http.MethodPost,
+			body:       review("tnnt"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			body:       []byte("{"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, "/authorize", bytes.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			response := &authorizationv1.SubjectAccessReview{}
+			if err := json.NewDecoder(rec.Body).Decode(response); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+
+			if response.Status.Allowed {
+				t.Error("the authorization webhook must never allow requests")
+			}
+			if response.Status.Denied != tt.wantDenied {
+				t.Errorf("denied = %v, want %v", response.Status.Denied, tt.wantDenied)
+			}
+			if tt.wantDenied && response.Status.Reason == "" {
+				t.Error("expected a reason for the denial")
+			}
+		})
+	}
+}
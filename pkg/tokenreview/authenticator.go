@@ -0,0 +1,319 @@
+package tokenreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/metal-stack/metal-lib/jwt/grp"
+)
+
+var (
+	// ErrUnauthorized is returned when a token is valid but the user is not allowed to access the cluster.
+	ErrUnauthorized = errors.New("user is not allowed to access this cluster")
+	// ErrGroupResolution is returned when the groups of a user could not be resolved.
+	ErrGroupResolution = errors.New("unable to resolve groups")
+	// ErrSuspended is returned for all tokens but the break-glass tokens while the authentication is suspended.
+	ErrSuspended = errors.New("authentication with the issuer is suspended")
+	// ErrInvalidToken is returned when a token is expired, not signed by the issuer or misses required claims.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrIssuer is returned when a token could not be verified because the issuer cannot be reached.
+	ErrIssuer = errors.New("issuer unavailable")
+)
+
+// Config contains the settings of the authenticator.
+type Config struct {
+	// Issuer is the url of the oidc issuer.
+	Issuer string
+	// ClientID is the client id the tokens must be issued for.
+	ClientID string
+	// Tenant is the tenant that owns the cluster.
+	Tenant string
+	// ProviderTenant is the tenant of the provider, its members are allowed to access every cluster.
+	ProviderTenant string
+	// AdditionalTenants are tenants besides the owning tenant whose members are allowed to access the cluster.
+	// The groups of their members are prefixed with "<tenant>:" such that tenants cannot grant each other access.
+	AdditionalTenants []string
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Project is the id of the metal project the cluster belongs to.
+	Project string
+	// ProjectScoped restricts the access to members of the project of the cluster. Members of the
+	// provider tenant are still allowed to access the cluster.
+	ProjectScoped bool
+	// GroupsPrefixToRemove is the application prefix of the groups that are meant for this cluster, e.g. "k8s".
+	GroupsPrefixToRemove string
+	// ClaimMapping defines which token claims hold the identity of the user.
+	ClaimMapping ClaimMapping
+	// Timeout is the timeout for authenticating a token, no timeout is applied if zero.
+	Timeout time.Duration
+	// Suspended rejects all tokens except the break-glass tokens.
+	Suspended bool
+}
+
+// ClaimMapping defines which token claims hold the identity of the user.
+type ClaimMapping struct {
+	// Username is the claim that contains the name of the user, defaults to "email".
+	Username string
+	// Groups is the claim that contains the groups of the user, defaults to "groups".
+	Groups string
+	// Tenant is the claim that contains the tenant of the user. If empty, the tenant is
+	// derived from the connector id of the federated claims, e.g. "tnnt_ldap".
+	Tenant string
+}
+
+// User is the identity that was extracted from a verified token.
+type User struct {
+	// Name is the name of the user.
+	Name string
+	// Tenant is the tenant the user belongs to.
+	Tenant string
+	// Directory is the type of the directory the user comes from, e.g. "ldap" or "ad".
+	Directory string
+	// TokenGroups are the groups as contained in the token.
+	TokenGroups []string
+	// Groups are the kubernetes groups that were derived from the token groups.
+	Groups []string
+}
+
+// Authenticator authenticates bearer tokens.
+type Authenticator struct {
+	log      logr.Logger
+	config   Config
+	verifier TokenVerifier
+	resolver GroupResolver
+	grpr     *grp.Grpr
+
+	breakGlass *BreakGlass
+}
+
+// NewAuthenticator returns a new authenticator.
+func NewAuthenticator(log logr.Logger, config Config, verifier TokenVerifier, resolver GroupResolver) (*Authenticator, error) {
+	if config.ClaimMapping.Username == "" {
+		config.ClaimMapping.Username = "email"
+	}
+	if config.ClaimMapping.Groups == "" {
+		config.ClaimMapping.Groups = "groups"
+	}
+
+	grpr, err := grp.NewGrpr(grp.Config{ProviderTenant: config.ProviderTenant})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{
+		log:      log,
+		config:   config,
+		verifier: verifier,
+		resolver: resolver,
+		grpr:     grpr,
+	}, nil
+}
+
+// WithBreakGlass makes the authenticator accept the emergency tokens of the given break-glass authenticator.
+func (a *Authenticator) WithBreakGlass(b *BreakGlass) *Authenticator {
+	a.breakGlass = b
+	return a
+}
+
+// Authenticate verifies the given token and returns the user it belongs to.
+func (a *Authenticator) Authenticate(ctx context.Context, token string) (*User, error) {
+	if a.breakGlass != nil {
+		user, ok, err := a.breakGlass.Authenticate(token)
+		if err != nil {
+			a.log.Error(err, "unable to check break-glass tokens")
+		}
+		if ok {
+			return user, nil
+		}
+	}
+
+	if a.config.Suspended {
+		return nil, ErrSuspended
+	}
+
+	if a.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.config.Timeout)
+		defer cancel()
+	}
+
+	claims, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		if issuerError(err) {
+			return nil, fmt.Errorf("%w: token verification failed: %w", ErrIssuer, err)
+		}
+		return nil, fmt.Errorf("%w: token verification failed: %w", ErrInvalidToken, err)
+	}
+
+	user, err := a.mapClaims(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if !a.isAllowed(user) {
+		return nil, ErrUnauthorized
+	}
+
+	groups, err := a.resolver.Resolve(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGroupResolution, err)
+	}
+
+	if a.isAdditionalTenant(user.Tenant) {
+		for i := range groups {
+			groups[i] = user.Tenant + ":" + groups[i]
+		}
+	}
+
+	user.Groups = groups
+
+	return user, nil
+}
+
+// issuerError returns true if the given verification error is caused by the issuer instead of the token.
+func issuerError(err error) bool {
+	if errors.Is(err, ErrIssuer) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	// go-oidc does not wrap the errors of fetching the key set of the issuer
+	return strings.Contains(err.Error(), "fetching keys")
+}
+
+func (a *Authenticator) mapClaims(claims map[string]any) (*User, error) {
+	name, ok := claims[a.config.ClaimMapping.Username].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("token does not contain username claim %q", a.config.ClaimMapping.Username)
+	}
+
+	user := &User{
+		Name: name,
+	}
+
+	if raw, ok := claims[a.config.ClaimMapping.Groups].([]any); ok {
+		for _, g := range raw {
+			if s, ok := g.(string); ok {
+				user.TokenGroups = append(user.TokenGroups, s)
+			}
+		}
+	}
+
+	if a.config.ClaimMapping.Tenant != "" {
+		tenant, ok := claims[a.config.ClaimMapping.Tenant].(string)
+		if !ok || tenant == "" {
+			return nil, fmt.Errorf("token does not contain tenant claim %q", a.config.ClaimMapping.Tenant)
+		}
+		user.Tenant = strings.ToLower(tenant)
+		user.Directory = "ldap"
+	} else {
+		federated, _ := claims["federated_claims"].(map[string]any)
+		connectorID, _ := federated["connector_id"].(string)
+
+		tenant, directory, err := grp.ParseConnectorId(connectorID)
+		if err != nil {
+			return nil, err
+		}
+		user.Tenant = strings.ToLower(tenant)
+		user.Directory = directory
+	}
+
+	user.Groups = a.clusterGroups(user)
+
+	return user, nil
+}
+
+// clusterGroups returns the kubernetes groups for this cluster, i.e. the token groups of the application
+// prefix that refer to this cluster with the prefix removed, e.g. "tnnt_k8s-mycluster-default-admin"
+// results in "mycluster-default-admin".
+func (a *Authenticator) clusterGroups(user *User) []string {
+	parse, err := a.grpr.SelectGroupParseFunc(user.Directory)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		cluster = a.grpr.GroupEncodeName(strings.ToLower(a.config.Cluster))
+		tenant  = strings.ToLower(a.config.Tenant)
+		groups  []string
+	)
+
+	for _, tg := range user.TokenGroups {
+		gc, err := parse(tg)
+		if err != nil {
+			continue
+		}
+
+		if gc.AppPrefix != a.config.GroupsPrefixToRemove {
+			continue
+		}
+		if gc.FirstScope != cluster && gc.FirstScope != grp.All {
+			continue
+		}
+		if gc.OnBehalfTenant != "" && gc.OnBehalfTenant != tenant {
+			continue
+		}
+
+		// names containing dashes are encoded with dollar signs in groups
+		namespace := strings.ReplaceAll(gc.SecondScope, "$", "-")
+
+		groups = append(groups, fmt.Sprintf("%s-%s-%s", a.config.Cluster, namespace, gc.Role))
+	}
+
+	slices.Sort(groups)
+	return slices.Compact(groups)
+}
+
+func (a *Authenticator) isAllowed(user *User) bool {
+	switch user.Tenant {
+	case strings.ToLower(a.config.ProviderTenant):
+		return true
+	case strings.ToLower(a.config.Tenant):
+		if a.config.ProjectScoped {
+			return a.isProjectMember(user)
+		}
+		return true
+	default:
+		return a.isAdditionalTenant(user.Tenant)
+	}
+}
+
+func (a *Authenticator) isAdditionalTenant(tenant string) bool {
+	return slices.ContainsFunc(a.config.AdditionalTenants, func(t string) bool {
+		return strings.ToLower(t) == tenant
+	})
+}
+
+// isProjectMember returns true if the user has any role in the project of the cluster,
+// i.e. a token group like "tnnt_kaas-<project>-<cluster>-<role>" or "tnnt_kaas-<project>-all-<role>".
+func (a *Authenticator) isProjectMember(user *User) bool {
+	if a.config.Project == "" {
+		return false
+	}
+
+	parse, err := a.grpr.SelectGroupParseFunc(user.Directory)
+	if err != nil {
+		return false
+	}
+
+	var (
+		project = a.grpr.GroupEncodeName(strings.ToLower(a.config.Project))
+		cluster = a.grpr.GroupEncodeName(strings.ToLower(a.config.Cluster))
+	)
+
+	for _, tg := range user.TokenGroups {
+		gc, err := parse(tg)
+		if err != nil || gc.AppPrefix != metalProjectAppPrefix {
+			continue
+		}
+
+		if gc.FirstScope == project && (gc.SecondScope == cluster || gc.SecondScope == grp.All) {
+			return true
+		}
+	}
+
+	return false
+}
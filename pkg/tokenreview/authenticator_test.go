@@ -0,0 +1,241 @@
+package tokenreview
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// ldapClaims returns the claims of a user of the given tenant that logs in through the ldap connector.
+func ldapClaims(email, tenant string, groups ...string) map[string]any {
+	return map[string]any{
+		"email":  email,
+		"groups": groups,
+		"federated_claims": map[string]any{
+			"connector_id": tenant + "_ldap",
+		},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	config := Config{
+		Issuer:               issuer.URL,
+		ClientID:             testClientID,
+		Tenant:               "tnnt",
+		ProviderTenant:       "prvdr",
+		AdditionalTenants:    []string{"Partner"},
+		Cluster:              "my-cluster",
+		Project:              "project-1",
+		GroupsPrefixToRemove: "k8s",
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(*Config)
+		claims     map[string]any
+		token      string
+		wantName   string
+		wantTenant string
+		wantGroups []string
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "member of the owning tenant",
+			claims:     ldapClaims("user@tnnt.example", "tnnt", "tnnt_k8s-my$cluster-kube$system-admin", "tnnt_k8s-all-all-view", "tnnt_other-my$cluster-default-admin", "tnnt_k8s-foreign-default-admin"),
+			wantName:   "user@tnnt.example",
+			wantTenant: "tnnt",
+			wantGroups: []string{"my-cluster-all-view", "my-cluster-kube-system-admin"},
+		},
+		{
+			name:       "member of the provider tenant",
+			claims:     ldapClaims("admin@prvdr.example", "PRVDR", "prvdr_k8s-tnnt#my$cluster-default-admin", "prvdr_k8s-other#my$cluster-default-view"),
+			wantName:   "admin@prvdr.example",
+			wantTenant: "prvdr",
+			wantGroups: []string{"my-cluster-default-admin"},
+		},
+		{
+			name:       "member of an additional tenant",
+			claims:     ldapClaims("user@partner.example", "partner", "partner_k8s-my$cluster-default-edit"),
+			wantName:   "user@partner.example",
+			wantTenant: "partner",
+			wantGroups: []string{"partner:my-cluster-default-edit"},
+		},
+		{
+			name:    "member of a foreign tenant",
+			claims:  ldapClaims("user@foreign.example", "foreign", "foreign_k8s-my$cluster-default-admin"),
+			wantErr: ErrUnauthorized,
+		},
+		{
+			name: "custom claim mapping",
+			modify: func(c *Config) {
+				c.ClaimMapping = ClaimMapping{Username: "preferred_username", Groups: "roles", Tenant: "tenant"}
+			},
+			claims: map[string]any{
+				"preferred_username": "user",
+				"tenant":             "TNNT",
+				"roles":              []string{"tnnt_k8s-my$cluster-default-view"},
+			},
+			wantName:   "user",
+			wantTenant: "tnnt",
+			wantGroups: []string{"my-cluster-default-view"},
+		},
+		{
+			name:       "missing username claim",
+			claims:     map[string]any{"federated_claims": map[string]any{"connector_id": "tnnt_ldap"}},
+			wantErrMsg: `token does not contain username claim "email"`,
+		},
+		{
+			name:       "missing tenant claim",
+			modify:     func(c *Config) { c.ClaimMapping.Tenant = "tenant" },
+			claims:     map[string]any{"email": "user@tnnt.example"},
+			wantErrMsg: `token does not contain tenant claim "tenant"`,
+		},
+		{
+			name:       "missing connector id",
+			claims:     map[string]any{"email": "user@tnnt.example"},
+			wantErrMsg: "error parsing connectorId",
+		},
+		{
+			name:       "project member with project scope",
+			modify:     func(c *Config) { c.ProjectScoped = true },
+			claims:     ldapClaims("user@tnnt.example", "tnnt", "tnnt_kaas-project$1-my$cluster-admin", "tnnt_k8s-my$cluster-default-admin"),
+			wantName:   "user@tnnt.example",
+			wantTenant: "tnnt",
+			wantGroups: []string{"my-cluster-default-admin"},
+		},
+		{
+			name:       "member of all clusters of the project with project scope",
+			modify:     func(c *Config) { c.ProjectScoped = true },
+			claims:     ldapClaims("user@tnnt.example", "tnnt", "tnnt_kaas-project$1-all-view"),
+			wantName:   "user@tnnt.example",
+			wantTenant: "tnnt",
+		},
+		{
+			name:    "member of another project with project scope",
+			modify:  func(c *Config) { c.ProjectScoped = true },
+			claims:  ldapClaims("user@tnnt.example", "tnnt", "tnnt_kaas-project$2-my$cluster-admin", "tnnt_k8s-my$cluster-default-admin"),
+			wantErr: ErrUnauthorized,
+		},
+		{
+			name:       "provider tenant with project scope",
+			modify:     func(c *Config) { c.ProjectScoped = true },
+			claims:     ldapClaims("admin@prvdr.example", "prvdr"),
+			wantName:   "admin@prvdr.example",
+			wantTenant: "prvdr",
+		},
+		{
+			name:       "expired token",
+			token:      issuer.token(t, map[string]any{"email": "user@tnnt.example", "exp": time.Now().Add(-time.Minute).Unix()}),
+			wantErrMsg: "token verification failed",
+		},
+		{
+			name:       "wrong audience",
+			token:      issuer.token(t, map[string]any{"email": "user@tnnt.example", "aud": "other"}),
+			wantErrMsg: "token verification failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config
+			if tt.modify != nil {
+				tt.modify(&cfg)
+			}
+
+			a, err := NewAuthenticator(logr.Discard(), cfg, NewOIDCVerifier(cfg.Issuer, cfg.ClientID, ""), NewNoopResolver())
+			if err != nil {
+				t.Fatalf("NewAuthenticator() error = %v", err)
+			}
+
+			token := tt.token
+			if token == "" {
+				token = issuer.token(t, tt.claims)
+			}
+
+			user, err := a.Authenticate(context.Background(), token)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			case tt.wantErrMsg != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Authenticate() error = %v, want error containing %q", err, tt.wantErrMsg)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+
+			if user.Name != tt.wantName {
+				t.Errorf("user name = %q, want %q", user.Name, tt.wantName)
+			}
+			if user.Tenant != tt.wantTenant {
+				t.Errorf("user tenant = %q, want %q", user.Tenant, tt.wantTenant)
+			}
+			if !slices.Equal(user.Groups, tt.wantGroups) {
+				t.Errorf("user groups = %v, want %v", user.Groups, tt.wantGroups)
+			}
+		})
+	}
+}
+
+type failingResolver struct{}
+
+func (failingResolver) Resolve(context.Context, *User) ([]string, error) {
+	return nil, errors.New("backend unavailable")
+}
+
+func TestAuthenticateResolverError(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	a, err := NewAuthenticator(logr.Discard(), Config{Tenant: "tnnt", ProviderTenant: "prvdr", Cluster: "my-cluster"}, NewOIDCVerifier(issuer.URL, testClientID, ""), failingResolver{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = a.Authenticate(context.Background(), issuer.token(t, ldapClaims("user@tnnt.example", "tnnt")))
+	if !errors.Is(err, ErrGroupResolution) {
+		t.Errorf("Authenticate() error = %v, want %v", err, ErrGroupResolution)
+	}
+}
+
+func TestAuthenticateSuspended(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	path := filepath.Join(t.TempDir(), "tokens.csv")
+	writeFile(t, path, "emergency-token,fits-authn:break-glass,fits-authn:break-glass,fits-authn:break-glass\n")
+
+	breakGlass, err := NewBreakGlass(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := NewAuthenticator(logr.Discard(), Config{Tenant: "tnnt", ProviderTenant: "prvdr", Cluster: "my-cluster", Suspended: true}, NewOIDCVerifier(issuer.URL, testClientID, ""), NewNoopResolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.WithBreakGlass(breakGlass)
+
+	if _, err := a.Authenticate(context.Background(), issuer.token(t, ldapClaims("user@tnnt.example", "tnnt"))); !errors.Is(err, ErrSuspended) {
+		t.Errorf("Authenticate() error = %v, want %v", err, ErrSuspended)
+	}
+
+	user, err := a.Authenticate(context.Background(), "emergency-token")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if user.Name != "fits-authn:break-glass" {
+		t.Errorf("user name = %q, want the break-glass user", user.Name)
+	}
+}
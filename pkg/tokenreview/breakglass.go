@@ -0,0 +1,90 @@
+package tokenreview
+
+import (
+	"crypto/subtle"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
+)
+
+// BreakGlass accepts the emergency tokens of a cluster. The tokens are checked locally, such that the cluster
+// can still be accessed if the issuer or the membership backend are unavailable.
+type BreakGlass struct {
+	path  string
+	onUse func(user *User)
+
+	mu      sync.Mutex
+	modTime time.Time
+	tokens  []secretsutils.Token
+}
+
+// NewBreakGlass returns a new break-glass authenticator for the tokens in the given static token file. The file
+// is read again when it changes, such that rotated tokens are picked up. onUse is called for every authentication
+// with an emergency token.
+func NewBreakGlass(path string, onUse func(user *User)) (*BreakGlass, error) {
+	b := &BreakGlass{path: path, onUse: onUse}
+	if _, err := b.load(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Authenticate returns the user of the given token, or false if it is not an emergency token.
+func (b *BreakGlass) Authenticate(token string) (*User, bool, error) {
+	tokens, err := b.load()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) != 1 {
+			continue
+		}
+
+		user := &User{
+			Name:   t.Username,
+			Groups: t.Groups,
+		}
+		breakGlassLogins.Inc()
+		if b.onUse != nil {
+			b.onUse(user)
+		}
+		return user, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (b *BreakGlass) load() ([]secretsutils.Token, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	info, err := os.Stat(b.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.ModTime().Equal(b.modTime) {
+		return b.tokens, nil
+	}
+
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		return nil, err
+	}
+
+	staticToken, err := secretsutils.LoadStaticTokenFromCSV("break-glass", []byte(strings.TrimSpace(string(data))))
+	if err != nil {
+		// the error contains the file content, so it must not be passed on
+		return nil, errors.New("unable to parse break-glass tokens")
+	}
+
+	b.tokens = staticToken.Tokens
+	b.modTime = info.ModTime()
+
+	return b.tokens, nil
+}
@@ -0,0 +1,242 @@
+package tokenreview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/metal-stack/metal-lib/jwt/grp"
+	"github.com/metal-stack/security"
+)
+
+const (
+	// metalProjectAppPrefix is the application prefix of groups that grant permissions on metal projects,
+	// e.g. "tnnt_kaas-<project>-<cluster>-<role>".
+	metalProjectAppPrefix = "kaas"
+
+	metalProjectCacheTTL = 5 * time.Minute
+)
+
+// MetalAPIConfig contains the settings to connect to the metal-api.
+type MetalAPIConfig struct {
+	// URL is the base url of the metal-api.
+	URL string
+	// HMAC is the shared key for the hmac authentication.
+	HMAC string
+	// AuthType is the type of the hmac authentication, e.g. "Metal-View".
+	AuthType string
+}
+
+// MetalV2Config contains the settings to connect to the metal-stack v2 api.
+type MetalV2Config struct {
+	// URL is the base url of the api.
+	URL string
+	// Token is the api token used for authentication.
+	Token string
+}
+
+// projectLister lists the ids of the metal projects of a tenant.
+type projectLister interface {
+	listProjects(ctx context.Context, tenant string) ([]string, error)
+}
+
+type metalResolver struct {
+	lister  projectLister
+	tenant  string
+	cluster string
+	grpr    *grp.Grpr
+
+	mu        sync.Mutex
+	projects  []string
+	fetchedAt time.Time
+}
+
+// NewMetalAPIResolver returns a resolver that grants cluster wide groups to members of the metal projects
+// of the cluster tenant. A token group "tnnt_kaas-<project>-<cluster>-<role>" or "tnnt_kaas-<project>-all-<role>"
+// results in the group "<cluster>-all-<role>" if the project belongs to the tenant of the cluster.
+func NewMetalAPIResolver(config MetalAPIConfig, tenant, cluster, providerTenant string) (GroupResolver, error) {
+	return newMetalResolver(&metalAPIProjects{
+		url:    config.URL,
+		hmac:   security.NewHMACAuth(config.AuthType, []byte(config.HMAC)),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, tenant, cluster, providerTenant)
+}
+
+// NewMetalV2Resolver returns a resolver that works like the one of NewMetalAPIResolver but queries
+// the projects from the metal-stack v2 api.
+func NewMetalV2Resolver(config MetalV2Config, tenant, cluster, providerTenant string) (GroupResolver, error) {
+	return newMetalResolver(&metalV2Projects{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, tenant, cluster, providerTenant)
+}
+
+func newMetalResolver(lister projectLister, tenant, cluster, providerTenant string) (GroupResolver, error) {
+	grpr, err := grp.NewGrpr(grp.Config{ProviderTenant: providerTenant})
+	if err != nil {
+		return nil, err
+	}
+
+	return &metalResolver{
+		lister:  lister,
+		tenant:  tenant,
+		cluster: cluster,
+		grpr:    grpr,
+	}, nil
+}
+
+// Resolve implements GroupResolver.
+func (r *metalResolver) Resolve(ctx context.Context, user *User) ([]string, error) {
+	groups := slices.Clone(user.Groups)
+
+	parse, err := r.grpr.SelectGroupParseFunc(user.Directory)
+	if err != nil {
+		return groups, nil
+	}
+
+	var (
+		cluster  = r.grpr.GroupEncodeName(strings.ToLower(r.cluster))
+		projects []string
+	)
+
+	for _, tg := range user.TokenGroups {
+		gc, err := parse(tg)
+		if err != nil || gc.AppPrefix != metalProjectAppPrefix {
+			continue
+		}
+		if gc.SecondScope != cluster && gc.SecondScope != grp.All {
+			continue
+		}
+
+		if projects == nil {
+			projects, err = r.tenantProjects(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		project := strings.ReplaceAll(gc.FirstScope, "$", "-")
+		if !slices.Contains(projects, project) {
+			continue
+		}
+
+		groups = append(groups, fmt.Sprintf("%s-%s-%s", r.cluster, grp.All, gc.Role))
+	}
+
+	slices.Sort(groups)
+	return slices.Compact(groups), nil
+}
+
+func (r *metalResolver) tenantProjects(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.projects != nil && time.Since(r.fetchedAt) < metalProjectCacheTTL {
+		return r.projects, nil
+	}
+
+	projects, err := r.lister.listProjects(ctx, r.tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	r.projects = projects
+	r.fetchedAt = time.Now()
+
+	return r.projects, nil
+}
+
+type metalAPIProjects struct {
+	url    string
+	hmac   security.HMACAuth
+	client *http.Client
+}
+
+func (m *metalAPIProjects) listProjects(ctx context.Context, tenant string) ([]string, error) {
+	body, err := json.Marshal(map[string]string{"tenant_id": tenant})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(m.url, "/")+"/v1/project/find", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	m.hmac.AddAuth(req, time.Now(), body)
+
+	var found []struct {
+		Meta struct {
+			ID string `json:"id"`
+		} `json:"meta"`
+	}
+	if err := doJSON(m.client, req, &found); err != nil {
+		return nil, fmt.Errorf("unable to query metal-api for projects: %w", err)
+	}
+
+	projects := []string{}
+	for _, p := range found {
+		projects = append(projects, p.Meta.ID)
+	}
+
+	return projects, nil
+}
+
+type metalV2Projects struct {
+	config MetalV2Config
+	client *http.Client
+}
+
+func (m *metalV2Projects) listProjects(ctx context.Context, tenant string) ([]string, error) {
+	body, err := json.Marshal(map[string]string{"tenant": tenant})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(m.config.URL, "/")+"/metalstack.admin.v2.ProjectService/List", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.config.Token)
+
+	var found struct {
+		Projects []struct {
+			UUID string `json:"uuid"`
+		} `json:"projects"`
+	}
+	if err := doJSON(m.client, req, &found); err != nil {
+		return nil, fmt.Errorf("unable to query metal-stack api for projects: %w", err)
+	}
+
+	projects := []string{}
+	for _, p := range found.Projects {
+		projects = append(projects, p.UUID)
+	}
+
+	return projects, nil
+}
+
+func doJSON(client *http.Client, req *http.Request, into any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(msg))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(into)
+}
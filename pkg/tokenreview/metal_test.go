@@ -0,0 +1,231 @@
+package tokenreview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/metal-stack/security"
+)
+
+const (
+	testHMACKey      = "metal-view-key"
+	testHMACAuthType = "Metal-View"
+)
+
+// newMetalAPI returns a stand-in for the metal-api that verifies the hmac authentication and returns the
+// projects of the given tenants.
+func newMetalAPI(t *testing.T, projects map[string][]string, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	hmac := security.NewHMACAuth(testHMACAuthType, []byte(testHMACKey))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/project/find" {
+			http.NotFound(w, r)
+			return
+		}
+		if _, err := hmac.User(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var query struct {
+			TenantID string `json:"tenant_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type project struct {
+			Meta struct {
+				ID string `json:"id"`
+			} `json:"meta"`
+		}
+		found := []project{}
+		for _, id := range projects[query.TenantID] {
+			p := project{}
+			p.Meta.ID = id
+			found = append(found, p)
+		}
+
+		_ = json.NewEncoder(w).Encode(found)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newMetalV2API returns a stand-in for the metal-stack v2 api that checks the bearer token and returns the
+// projects of the given tenants.
+func newMetalV2API(t *testing.T, token string, projects map[string][]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metalstack.admin.v2.ProjectService/List" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthenticated", http.StatusUnauthorized)
+			return
+		}
+
+		var query struct {
+			Tenant string `json:"tenant"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type project struct {
+			UUID string `json:"uuid"`
+		}
+		resp := struct {
+			Projects []project `json:"projects"`
+		}{Projects: []project{}}
+		for _, id := range projects[query.Tenant] {
+			resp.Projects = append(resp.Projects, project{UUID: id})
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestMetalResolver(t *testing.T) {
+	projects := map[string][]string{
+		"tnnt":  {"project-1", "project-2"},
+		"other": {"project-3"},
+	}
+
+	var requests atomic.Int32
+	metalAPI := newMetalAPI(t, projects, &requests)
+	metalV2API := newMetalV2API(t, "secret-token", projects)
+
+	resolvers := map[string]func(t *testing.T) GroupResolver{
+		"metal-api": func(t *testing.T) GroupResolver {
+			r, err := NewMetalAPIResolver(MetalAPIConfig{URL: metalAPI.URL, HMAC: testHMACKey, AuthType: testHMACAuthType}, "tnnt", "my-cluster", "prvdr")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return r
+		},
+		"metal-v2": func(t *testing.T) GroupResolver {
+			r, err := NewMetalV2Resolver(MetalV2Config{URL: metalV2API.URL, Token: "secret-token"}, "tnnt", "my-cluster", "prvdr")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return r
+		},
+	}
+
+	tests := []struct {
+		name string
+		user *User
+		want []string
+	}{
+		{
+			name: "no project groups",
+			user: &User{Directory: "ldap", TokenGroups: []string{"tnnt_k8s-my$cluster-default-admin"}, Groups: []string{"my-cluster-default-admin"}},
+			want: []string{"my-cluster-default-admin"},
+		},
+		{
+			name: "project of the tenant",
+			user: &User{Directory: "ldap", TokenGroups: []string{"tnnt_kaas-project$1-my$cluster-admin"}},
+			want: []string{"my-cluster-all-admin"},
+		},
+		{
+			name: "all clusters of a project",
+			user: &User{Directory: "ldap", TokenGroups: []string{"tnnt_kaas-project$2-all-view"}, Groups: []string{"my-cluster-default-admin"}},
+			want: []string{"my-cluster-all-view", "my-cluster-default-admin"},
+		},
+		{
+			name: "project of another tenant",
+			user: &User{Directory: "ldap", TokenGroups: []string{"tnnt_kaas-project$3-my$cluster-admin"}},
+			want: nil,
+		},
+		{
+			name: "project group of another cluster",
+			user: &User{Directory: "ldap", TokenGroups: []string{"tnnt_kaas-project$1-other$cluster-admin"}},
+			want: nil,
+		},
+	}
+
+	for name, newResolver := range resolvers {
+		t.Run(name, func(t *testing.T) {
+			r := newResolver(t)
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					got, err := r.Resolve(context.Background(), tt.user)
+					if err != nil {
+						t.Fatalf("Resolve() error = %v", err)
+					}
+					if !slices.Equal(got, tt.want) {
+						t.Errorf("Resolve() = %v, want %v", got, tt.want)
+					}
+				})
+			}
+		})
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("metal-api was queried %d times, want the projects to be cached after the first query", got)
+	}
+}
+
+func TestMetalResolverErrors(t *testing.T) {
+	var requests atomic.Int32
+	metalAPI := newMetalAPI(t, nil, &requests)
+	metalV2API := newMetalV2API(t, "secret-token", nil)
+
+	user := &User{Directory: "ldap", TokenGroups: []string{"tnnt_kaas-project$1-my$cluster-admin"}}
+
+	tests := []struct {
+		name     string
+		resolver func() (GroupResolver, error)
+	}{
+		{
+			name: "wrong hmac",
+			resolver: func() (GroupResolver, error) {
+				return NewMetalAPIResolver(MetalAPIConfig{URL: metalAPI.URL, HMAC: "wrong", AuthType: testHMACAuthType}, "tnnt", "my-cluster", "prvdr")
+			},
+		},
+		{
+			name: "wrong token",
+			resolver: func() (GroupResolver, error) {
+				return NewMetalV2Resolver(MetalV2Config{URL: metalV2API.URL, Token: "wrong"}, "tnnt", "my-cluster", "prvdr")
+			},
+		},
+		{
+			name: "unreachable api",
+			resolver: func() (GroupResolver, error) {
+				return NewMetalAPIResolver(MetalAPIConfig{URL: "http://127.0.0.1:1", HMAC: testHMACKey, AuthType: testHMACAuthType}, "tnnt", "my-cluster", "prvdr")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.resolver()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := r.Resolve(context.Background(), user); err == nil {
+				t.Error("Resolve() expected error")
+			}
+		})
+	}
+}
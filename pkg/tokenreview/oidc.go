@@ -0,0 +1,83 @@
+package tokenreview
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// TokenVerifier verifies a raw token and returns its claims.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (map[string]any, error)
+}
+
+type oidcVerifier struct {
+	issuer   string
+	clientID string
+	jwksURL  string
+
+	mu       sync.Mutex
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOIDCVerifier returns a verifier for tokens of the given issuer. The signing keys are fetched from
+// the jwks endpoint announced in the discovery document of the issuer. If jwksURL is not empty, the discovery
+// is skipped and the keys are fetched from the given url.
+//
+// The discovery happens on first use, such that an unreachable issuer does not prevent the webhook from starting.
+func NewOIDCVerifier(issuer, clientID, jwksURL string) TokenVerifier {
+	return &oidcVerifier{
+		issuer:   issuer,
+		clientID: clientID,
+		jwksURL:  jwksURL,
+	}
+}
+
+// Verify implements TokenVerifier.
+func (v *oidcVerifier) Verify(ctx context.Context, token string) (map[string]any, error) {
+	verifier, err := v.getVerifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("unable to decode claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+func (v *oidcVerifier) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.verifier != nil {
+		return v.verifier, nil
+	}
+
+	// the key set keeps using the context for refreshing the keys, so it must not be bound to a single request
+	ctx = context.WithoutCancel(ctx)
+	config := &oidc.Config{ClientID: v.clientID}
+
+	if v.jwksURL != "" {
+		v.verifier = oidc.NewVerifier(v.issuer, oidc.NewRemoteKeySet(ctx, v.jwksURL), config)
+		return v.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, v.issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to discover issuer %s: %w", ErrIssuer, v.issuer, err)
+	}
+
+	v.verifier = provider.Verifier(config)
+
+	return v.verifier, nil
+}
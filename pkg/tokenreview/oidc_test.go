@@ -0,0 +1,162 @@
+package tokenreview
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	testClientID = "kubernetes"
+	testKeyID    = "test-key"
+)
+
+// testIssuer is a local oidc issuer that serves a discovery document and a jwks with a generated key.
+type testIssuer struct {
+	*httptest.Server
+	signer jose.Signer
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", testKeyID))
+	if err != nil {
+		t.Fatalf("unable to create signer: %v", err)
+	}
+
+	issuer := &testIssuer{signer: signer}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                issuer.URL,
+			"jwks_uri":                              issuer.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: testKeyID, Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+// token returns a signed token of the issuer with the given claims. The registered claims default to a valid token
+// for the test client and can be overwritten through the given claims.
+func (i *testIssuer) token(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	all := map[string]any{
+		"iss": i.URL,
+		"aud": testClientID,
+		"sub": "subject",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		all[k] = v
+	}
+
+	token, err := jwt.Signed(i.signer).Claims(all).Serialize()
+	if err != nil {
+		t.Fatalf("unable to sign token: %v", err)
+	}
+	return token
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	issuer := newTestIssuer(t)
+	other := newTestIssuer(t)
+
+	tests := []struct {
+		name    string
+		jwksURL string
+		token   string
+		wantErr string
+	}{
+		{
+			name:  "valid token",
+			token: issuer.token(t, map[string]any{"email": "user@example.com"}),
+		},
+		{
+			name:    "valid token with jwks url",
+			jwksURL: issuer.URL + "/keys",
+			token:   issuer.token(t, map[string]any{"email": "user@example.com"}),
+		},
+		{
+			name:    "expired token",
+			token:   issuer.token(t, map[string]any{"exp": time.Now().Add(-time.Minute).Unix()}),
+			wantErr: "expired",
+		},
+		{
+			name:    "wrong audience",
+			token:   issuer.token(t, map[string]any{"aud": "other-client"}),
+			wantErr: "audience",
+		},
+		{
+			name:    "wrong issuer",
+			token:   issuer.token(t, map[string]any{"iss": "https://other.example.com"}),
+			wantErr: "different provider",
+		},
+		{
+			name:    "foreign signing key",
+			token:   other.token(t, map[string]any{"iss": issuer.URL}),
+			wantErr: "signature",
+		},
+		{
+			name:    "malformed token",
+			token:   "not-a-token",
+			wantErr: "malformed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewOIDCVerifier(issuer.URL, testClientID, tt.jwksURL)
+
+			claims, err := v.Verify(context.Background(), tt.token)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if claims["email"] != "user@example.com" {
+				t.Errorf("Verify() claims = %v, want email claim", claims)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifierUnreachableIssuer(t *testing.T) {
+	issuer := newTestIssuer(t)
+	token := issuer.token(t, nil)
+
+	v := NewOIDCVerifier("http://127.0.0.1:1", testClientID, "")
+	if _, err := v.Verify(context.Background(), token); err == nil || !strings.Contains(err.Error(), "unable to discover issuer") {
+		t.Errorf("Verify() error = %v, want discovery error", err)
+	}
+}
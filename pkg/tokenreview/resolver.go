@@ -0,0 +1,148 @@
+package tokenreview
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+)
+
+// GroupResolver resolves the kubernetes groups of an authenticated user.
+type GroupResolver interface {
+	// Resolve returns the kubernetes groups of the given user. The groups that were derived from the token
+	// are already contained in the user and serve as a starting point.
+	Resolve(ctx context.Context, user *User) ([]string, error)
+}
+
+type noopResolver struct{}
+
+// NewNoopResolver returns a resolver that returns the groups derived from the token as they are.
+func NewNoopResolver() GroupResolver {
+	return &noopResolver{}
+}
+
+// Resolve implements GroupResolver.
+func (*noopResolver) Resolve(_ context.Context, user *User) ([]string, error) {
+	return user.Groups, nil
+}
+
+type retryResolver struct {
+	log          logr.Logger
+	resolver     GroupResolver
+	retries      int
+	initialDelay time.Duration
+}
+
+// NewRetryResolver returns a resolver that retries failed resolutions of the given resolver. The delay before
+// the first retry is doubled for every further retry, retries stop when the context is done.
+func NewRetryResolver(log logr.Logger, resolver GroupResolver, retries int, initialDelay time.Duration) GroupResolver {
+	if retries <= 0 {
+		return resolver
+	}
+
+	return &retryResolver{
+		log:          log,
+		resolver:     resolver,
+		retries:      retries,
+		initialDelay: initialDelay,
+	}
+}
+
+// Resolve implements GroupResolver.
+func (r *retryResolver) Resolve(ctx context.Context, user *User) ([]string, error) {
+	delay := r.initialDelay
+
+	for attempt := 0; ; attempt++ {
+		groups, err := r.resolver.Resolve(ctx, user)
+		if err == nil || attempt >= r.retries {
+			return groups, err
+		}
+
+		r.log.Info("group resolution failed, retrying", "user", user.Name, "attempt", attempt+1, "delay", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// StaticGroups maps users and token groups to additional kubernetes groups.
+type StaticGroups struct {
+	// Users maps user names to kubernetes groups.
+	Users map[string][]string `json:"users,omitempty"`
+	// Groups maps token groups to kubernetes groups.
+	Groups map[string][]string `json:"groups,omitempty"`
+}
+
+type staticResolver struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	groups  StaticGroups
+}
+
+// NewStaticResolver returns a resolver that adds groups from the given file. The file contains StaticGroups
+// in yaml format, it is read again when it changes such that it can be mounted from a config map.
+func NewStaticResolver(path string) (GroupResolver, error) {
+	r := &staticResolver{path: path}
+	if _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Resolve implements GroupResolver.
+func (r *staticResolver) Resolve(_ context.Context, user *User) ([]string, error) {
+	static, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := slices.Clone(user.Groups)
+	groups = append(groups, static.Users[user.Name]...)
+	for _, tg := range user.TokenGroups {
+		groups = append(groups, static.Groups[tg]...)
+	}
+
+	slices.Sort(groups)
+	return slices.Compact(groups), nil
+}
+
+func (r *staticResolver) load() (StaticGroups, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return StaticGroups{}, err
+	}
+
+	if info.ModTime().Equal(r.modTime) {
+		return r.groups, nil
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return StaticGroups{}, err
+	}
+
+	var groups StaticGroups
+	if err := yaml.UnmarshalStrict(data, &groups); err != nil {
+		return StaticGroups{}, fmt.Errorf("unable to parse static groups file %s: %w", r.path, err)
+	}
+
+	r.groups = groups
+	r.modTime = info.ModTime()
+
+	return r.groups, nil
+}
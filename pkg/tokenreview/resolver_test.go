@@ -0,0 +1,163 @@
+package tokenreview
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+func TestNoopResolver(t *testing.T) {
+	user := &User{Name: "user", Groups: []string{"cluster-default-admin"}}
+
+	groups, err := NewNoopResolver().Resolve(context.Background(), user)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !slices.Equal(groups, user.Groups) {
+		t.Errorf("Resolve() = %v, want %v", groups, user.Groups)
+	}
+}
+
+func TestStaticResolver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.yaml")
+	writeFile(t, path, `
+users:
+  user@tnnt.example:
+  - cluster-all-admin
+groups:
+  tnnt_ops:
+  - cluster-all-view
+  - cluster-default-admin
+`)
+
+	r, err := NewStaticResolver(path)
+	if err != nil {
+		t.Fatalf("NewStaticResolver() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		user *User
+		want []string
+	}{
+		{
+			name: "unknown user",
+			user: &User{Name: "other@tnnt.example", Groups: []string{"cluster-default-view"}},
+			want: []string{"cluster-default-view"},
+		},
+		{
+			name: "user mapping",
+			user: &User{Name: "user@tnnt.example", Groups: []string{"cluster-default-view"}},
+			want: []string{"cluster-all-admin", "cluster-default-view"},
+		},
+		{
+			name: "token group mapping",
+			user: &User{Name: "other@tnnt.example", TokenGroups: []string{"tnnt_ops"}, Groups: []string{"cluster-default-admin"}},
+			want: []string{"cluster-all-view", "cluster-default-admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), tt.user)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("reload on change", func(t *testing.T) {
+		writeFile(t, path, "users:\n  user@tnnt.example:\n  - cluster-all-view\n")
+		// make sure the modification time differs on file systems with a coarse resolution
+		later := time.Now().Add(time.Minute)
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := r.Resolve(context.Background(), &User{Name: "user@tnnt.example"})
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if want := []string{"cluster-all-view"}; !slices.Equal(got, want) {
+			t.Errorf("Resolve() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+		writeFile(t, invalid, "unknown: field\n")
+
+		if _, err := NewStaticResolver(invalid); err == nil {
+			t.Error("NewStaticResolver() expected error for unknown fields")
+		}
+	})
+}
+
+type flakyResolver struct {
+	failures int
+	calls    int
+}
+
+func (f *flakyResolver) Resolve(_ context.Context, user *User) ([]string, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("temporary failure")
+	}
+	return user.Groups, nil
+}
+
+func TestRetryResolver(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int
+		retries   int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "no retries", failures: 1, retries: 0, wantCalls: 1, wantErr: true},
+		{name: "succeeds after retry", failures: 2, retries: 2, wantCalls: 3},
+		{name: "retries exhausted", failures: 3, retries: 2, wantCalls: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakyResolver{failures: tt.failures}
+			r := NewRetryResolver(logr.Discard(), flaky, tt.retries, time.Millisecond)
+
+			_, err := r.Resolve(context.Background(), &User{Name: "user"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if flaky.calls != tt.wantCalls {
+				t.Errorf("resolver was called %d times, want %d", flaky.calls, tt.wantCalls)
+			}
+		})
+	}
+
+	t.Run("stops when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		flaky := &flakyResolver{failures: 10}
+		_, err := NewRetryResolver(logr.Discard(), flaky, 5, time.Hour).Resolve(ctx, &User{Name: "user"})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Resolve() error = %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
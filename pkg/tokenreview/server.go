@@ -0,0 +1,134 @@
+package tokenreview
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/subjectaccessreview"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var (
+	tokenReviews = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authn_webhook_tokenreviews_total",
+		Help: "Number of processed token reviews by result.",
+	}, []string{"result"})
+
+	tokenReviewDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "authn_webhook_tokenreview_duration_seconds",
+		Help:    "Duration of token reviews.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	breakGlassLogins = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "authn_webhook_break_glass_logins_total",
+		Help: "Number of authentications with break-glass tokens.",
+	})
+)
+
+const (
+	resultAuthenticated = "authenticated"
+	resultUnauthorized  = "unauthorized"
+	resultInvalid       = "invalid"
+	resultInvalidToken  = "invalid_token"
+	resultSuspended     = "suspended"
+	resultIssuerError   = "issuer_error"
+	resultResolverError = "resolver_error"
+	resultError         = "error"
+)
+
+// RegisterMetrics registers the metrics of the token review handler at the given registerer.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{tokenReviews, tokenReviewDuration, breakGlassLogins} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves token reviews of the kube-apiserver.
+type Handler struct {
+	log           logr.Logger
+	authenticator *Authenticator
+}
+
+// NewHandler returns a new token review handler.
+func NewHandler(log logr.Logger, authenticator *Authenticator) *Handler {
+	return &Handler{
+		log:           log,
+		authenticator: authenticator,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	defer func() {
+		tokenReviewDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	review := &authenticationv1.TokenReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		tokenReviews.WithLabelValues(resultInvalid).Inc()
+		http.Error(w, "unable to decode token review", http.StatusBadRequest)
+		return
+	}
+
+	review.Status = authenticationv1.TokenReviewStatus{}
+
+	user, err := h.authenticator.Authenticate(r.Context(), review.Spec.Token)
+	switch {
+	case err == nil:
+		tokenReviews.WithLabelValues(resultAuthenticated).Inc()
+		review.Status.Authenticated = true
+		review.Status.User = authenticationv1.UserInfo{
+			Username: user.Name,
+			Groups:   user.Groups,
+		}
+		// emergency users do not belong to a tenant
+		if user.Tenant != "" {
+			review.Status.User.Extra = map[string]authenticationv1.ExtraValue{
+				subjectaccessreview.ExtraTenant: {user.Tenant},
+			}
+		}
+	case errors.Is(err, ErrUnauthorized):
+		tokenReviews.WithLabelValues(resultUnauthorized).Inc()
+		h.log.Info("user not allowed", "error", err)
+		review.Status.Error = err.Error()
+	case errors.Is(err, ErrInvalidToken):
+		tokenReviews.WithLabelValues(resultInvalidToken).Inc()
+		h.log.Info("invalid token", "error", err)
+		review.Status.Error = err.Error()
+	case errors.Is(err, ErrSuspended):
+		tokenReviews.WithLabelValues(resultSuspended).Inc()
+		review.Status.Error = err.Error()
+	case errors.Is(err, ErrIssuer):
+		tokenReviews.WithLabelValues(resultIssuerError).Inc()
+		h.log.Error(err, "issuer unavailable")
+		review.Status.Error = err.Error()
+	case errors.Is(err, ErrGroupResolution):
+		tokenReviews.WithLabelValues(resultResolverError).Inc()
+		h.log.Error(err, "group resolution failed")
+		review.Status.Error = err.Error()
+	default:
+		tokenReviews.WithLabelValues(resultError).Inc()
+		h.log.Info("token review failed", "error", err)
+		review.Status.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.Error(err, "unable to encode token review response")
+	}
+}
@@ -0,0 +1,168 @@
+package tokenreview
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/subjectaccessreview"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func TestHandler(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	a, err := NewAuthenticator(logr.Discard(), Config{
+		Tenant:               "tnnt",
+		ProviderTenant:       "prvdr",
+		Cluster:              "my-cluster",
+		GroupsPrefixToRemove: "k8s",
+	}, NewOIDCVerifier(issuer.URL, testClientID, ""), NewNoopResolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewHandler(logr.Discard(), a)
+
+	suspended, err := NewAuthenticator(logr.Discard(), Config{Tenant: "tnnt", ProviderTenant: "prvdr", Cluster: "my-cluster", Suspended: true}, NewOIDCVerifier(issuer.URL, testClientID, ""), NewNoopResolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable, err := NewAuthenticator(logr.Discard(), Config{Tenant: "tnnt", ProviderTenant: "prvdr", Cluster: "my-cluster"}, NewOIDCVerifier("http://127.0.0.1:1", testClientID, ""), NewNoopResolver())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		handler           *Handler
+		method            string
+		body              []byte
+		wantStatus        int
+		wantResult        string
+		wantAuthenticated bool
+		wantUser          authenticationv1.UserInfo
+	}{
+		{
+			name:              "authenticated",
+			method:            http.MethodPost,
+			body:              tokenReview(t, issuer.token(t, ldapClaims("user@tnnt.example", "tnnt", "tnnt_k8s-my$cluster-default-admin"))),
+			wantStatus:        http.StatusOK,
+			wantResult:        resultAuthenticated,
+			wantAuthenticated: true,
+			wantUser: authenticationv1.UserInfo{
+				Username: "user@tnnt.example",
+				Groups:   []string{"my-cluster-default-admin"},
+				Extra:    map[string]authenticationv1.ExtraValue{subjectaccessreview.ExtraTenant: {"tnnt"}},
+			},
+		},
+		{
+			name:       "unauthorized",
+			method:     http.MethodPost,
+			body:       tokenReview(t, issuer.token(t, ldapClaims("user@foreign.example", "foreign"))),
+			wantStatus: http.StatusOK,
+			wantResult: resultUnauthorized,
+		},
+		{
+			name:       "invalid token",
+			method:     http.MethodPost,
+			body:       tokenReview(t, "invalid"),
+			wantStatus: http.StatusOK,
+			wantResult: resultInvalidToken,
+		},
+		{
+			name:       "suspended",
+			handler:    NewHandler(logr.Discard(), suspended),
+			method:     http.MethodPost,
+			body:       tokenReview(t, issuer.token(t, ldapClaims("user@tnnt.example", "tnnt"))),
+			wantStatus: http.StatusOK,
+			wantResult: resultSuspended,
+		},
+		{
+			name:       "issuer unreachable",
+			handler:    NewHandler(logr.Discard(), unreachable),
+			method:     http.MethodPost,
+			body:       tokenReview(t, issuer.token(t, ldapClaims("user@tnnt.example", "tnnt"))),
+			wantStatus: http.StatusOK,
+			wantResult: resultIssuerError,
+		},
+		{
+			name:       "invalid body",
+			method:     http.MethodPost,
+			body:       []byte("{"),
+			wantStatus: http.StatusBadRequest,
+			wantResult: resultInvalid,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler
+			if tt.handler != nil {
+				h = tt.handler
+			}
+
+			var before float64
+			if tt.wantResult != "" {
+				before = testutil.ToFloat64(tokenReviews.WithLabelValues(tt.wantResult))
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, "/authenticate", bytes.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantResult != "" {
+				if got := testutil.ToFloat64(tokenReviews.WithLabelValues(tt.wantResult)) - before; got != 1 {
+					t.Errorf("token reviews with result %q increased by %v, want 1", tt.wantResult, got)
+				}
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			review := &authenticationv1.TokenReview{}
+			if err := json.NewDecoder(rec.Body).Decode(review); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+
+			if review.Status.Authenticated != tt.wantAuthenticated {
+				t.Fatalf("authenticated = %v, want %v (error %q)", review.Status.Authenticated, tt.wantAuthenticated, review.Status.Error)
+			}
+			if !tt.wantAuthenticated {
+				if review.Status.Error == "" {
+					t.Error("expected an error in the token review status")
+				}
+				return
+			}
+
+			user := review.Status.User
+			if user.Username != tt.wantUser.Username || !slices.Equal(user.Groups, tt.wantUser.Groups) || !slices.Equal(user.Extra[subjectaccessreview.ExtraTenant], tt.wantUser.Extra[subjectaccessreview.ExtraTenant]) {
+				t.Errorf("user = %+v, want %+v", user, tt.wantUser)
+			}
+		})
+	}
+}
+
+func tokenReview(t *testing.T, token string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(&authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
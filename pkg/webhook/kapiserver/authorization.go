@@ -0,0 +1,193 @@
+package kapiserver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/subjectaccessreview"
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"github.com/gardener/gardener/pkg/resourcemanager/controller/garbagecollector/references"
+	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	authorizerName = "fits-authn"
+
+	authorizationModeFlag              = "--authorization-mode="
+	authorizationConfigFlag            = "--authorization-config="
+	authorizationWebhookConfigFileFlag = "--authorization-webhook-config-file="
+	authzWebhookConfigKey              = "authz-webhook-config.json"
+	authzWebhookConfigFile             = "/etc/webhook/config/" + authzWebhookConfigKey
+
+	// structuredAuthorizationVolumeName is the name of the kube-apiserver volume that gardener uses
+	// to mount the structured authorization configuration.
+	structuredAuthorizationVolumeName = "authorization-config"
+	structuredAuthorizationDataKey    = "config.yaml"
+	authorizationConfigMapNamePrefix  = "authn-webhook-authorization-config"
+)
+
+// ensureAuthorization adds the authorization webhook to the authorizer chain of the kube-apiserver. The webhook
+// is inserted before RBAC, otherwise it would never be asked about requests that RBAC allows.
+// With structured authorization, the configuration of gardener is copied into a config map of this extension
+// that additionally contains the webhook, because the one of gardener is immutable.
+func (e *ensurer) ensureAuthorization(ctx context.Context, namespace string, c *corev1.Container, template *corev1.PodTemplateSpec) error {
+	failurePolicy := e.config.Authorization.FailurePolicy
+
+	if !hasFlag(c, authorizationConfigFlag) {
+		// the kube-apiserver continues with the next authorizer if a webhook configured by flags fails
+		if failurePolicy != config.AuthorizationFailurePolicyNoOpinion {
+			return fmt.Errorf("kube-apiserver does not use structured authorization, the failure policy %s of the authorization webhook requires it", failurePolicy)
+		}
+		return ensureLegacyAuthorization(c)
+	}
+
+	idx := slices.IndexFunc(template.Spec.Volumes, func(v corev1.Volume) bool { return v.Name == structuredAuthorizationVolumeName })
+	if idx < 0 || template.Spec.Volumes[idx].ConfigMap == nil {
+		return fmt.Errorf("kube-apiserver uses structured authorization but has no %s volume", structuredAuthorizationVolumeName)
+	}
+	volume := &template.Spec.Volumes[idx]
+
+	if strings.HasPrefix(volume.ConfigMap.Name, authorizationConfigMapNamePrefix) {
+		// already replaced
+		return nil
+	}
+
+	original := &corev1.ConfigMap{}
+	if err := e.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: volume.ConfigMap.Name}, original); err != nil {
+		return fmt.Errorf("unable to read authorization configuration: %w", err)
+	}
+
+	authorizationConfig, err := withAuthorizationWebhook(original.Data[structuredAuthorizationDataKey], failurePolicy)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      authorizationConfigMapNamePrefix,
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			structuredAuthorizationDataKey: authorizationConfig,
+		},
+	}
+	if err := kubernetesutils.MakeUnique(cm); err != nil {
+		return err
+	}
+	if err := client.IgnoreAlreadyExists(e.client.Create(ctx, cm)); err != nil {
+		return err
+	}
+
+	volume.ConfigMap.Name = cm.Name
+	// the reference protects the config map from the garbage collection of the gardener-resource-manager
+	metav1.SetMetaDataAnnotation(&template.ObjectMeta, references.AnnotationKey(references.KindConfigMap, cm.Name), cm.Name)
+
+	return nil
+}
+
+// withAuthorizationWebhook returns the given structured authorization configuration with the webhook
+// inserted before the RBAC authorizer. The webhook is only asked about the requests of users authenticated by the
+// token webhook, such that the failure policy does not affect the system components of the cluster.
+func withAuthorizationWebhook(data string, failurePolicy config.AuthorizationFailurePolicy) (string, error) {
+	authorizationConfig := map[string]any{}
+	if err := yaml.Unmarshal([]byte(data), &authorizationConfig); err != nil {
+		return "", fmt.Errorf("unable to parse authorization configuration: %w", err)
+	}
+
+	authorizers, _ := authorizationConfig["authorizers"].([]any)
+
+	position := len(authorizers)
+	for i, a := range authorizers {
+		authorizer, _ := a.(map[string]any)
+		if authorizer["name"] == authorizerName {
+			return data, nil
+		}
+		if authorizer["type"] == "RBAC" && position == len(authorizers) {
+			position = i
+		}
+	}
+
+	webhook := map[string]any{
+		"type": "Webhook",
+		"name": authorizerName,
+		"webhook": map[string]any{
+			"timeout":                    "3s",
+			"authorizedTTL":              "5m",
+			"unauthorizedTTL":            "30s",
+			"subjectAccessReviewVersion": "v1",
+			"matchConditionSubjectAccessReviewVersion": "v1",
+			"failurePolicy": string(failurePolicy),
+			"connectionInfo": map[string]any{
+				"type":           "KubeConfigFile",
+				"kubeConfigFile": authzWebhookConfigFile,
+			},
+			"matchConditions": []any{
+				map[string]any{
+					"expression": "has(request.extra) && '" + subjectaccessreview.ExtraTenant + "' in request.extra",
+				},
+			},
+		},
+	}
+
+	authorizationConfig["authorizers"] = slices.Insert(authorizers, position, any(webhook))
+
+	out, err := yaml.Marshal(authorizationConfig)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode authorization configuration: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// ensureLegacyAuthorization configures the authorization webhook with command line flags, which only support
+// a single webhook.
+func ensureLegacyAuthorization(c *corev1.Container) error {
+	for _, arg := range slices.Concat(c.Command, c.Args) {
+		if value, ok := strings.CutPrefix(arg, authorizationWebhookConfigFileFlag); ok && value != authzWebhookConfigFile {
+			return fmt.Errorf("kube-apiserver already uses the authorization webhook config file %s, it cannot be combined with the fits-authn webhook", value)
+		}
+	}
+
+	for _, args := range [][]string{c.Command, c.Args} {
+		for i, arg := range args {
+			value, ok := strings.CutPrefix(arg, authorizationModeFlag)
+			if !ok {
+				continue
+			}
+
+			modes := strings.Split(value, ",")
+			if slices.Contains(modes, "Webhook") {
+				continue
+			}
+
+			position := slices.Index(modes, "RBAC")
+			if position < 0 {
+				position = len(modes)
+			}
+			args[i] = authorizationModeFlag + strings.Join(slices.Insert(modes, position, "Webhook"), ",")
+		}
+	}
+
+	if !hasFlag(c, authorizationModeFlag) {
+		return fmt.Errorf("kube-apiserver has no %s flag, unable to add the authorization webhook", strings.TrimSuffix(authorizationModeFlag, "="))
+	}
+
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, authorizationWebhookConfigFileFlag, authzWebhookConfigFile)
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--authorization-webhook-version=", "v1")
+
+	return nil
+}
+
+func hasFlag(c *corev1.Container, prefix string) bool {
+	return slices.ContainsFunc(slices.Concat(c.Command, c.Args), func(arg string) bool {
+		return strings.HasPrefix(arg, prefix)
+	})
+}
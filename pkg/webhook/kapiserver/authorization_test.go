@@ -0,0 +1,110 @@
+package kapiserver
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestWithAuthorizationWebhook(t *testing.T) {
+	const gardenerConfig = `apiVersion: apiserver.config.k8s.io/v1beta1
+kind: AuthorizationConfiguration
+authorizers:
+- type: Node
+  name: node
+- type: RBAC
+  name: rbac
+`
+
+	for _, policy := range []config.AuthorizationFailurePolicy{config.AuthorizationFailurePolicyDeny, config.AuthorizationFailurePolicyNoOpinion} {
+		t.Run(string(policy), func(t *testing.T) {
+			out, err := withAuthorizationWebhook(gardenerConfig, policy)
+			if err != nil {
+				t.Fatalf("withAuthorizationWebhook() error = %v", err)
+			}
+
+			var parsed struct {
+				Authorizers []struct {
+					Type    string `json:"type"`
+					Name    string `json:"name"`
+					Webhook *struct {
+						FailurePolicy   string `json:"failurePolicy"`
+						MatchConditions []struct {
+							Expression string `json:"expression"`
+						} `json:"matchConditions"`
+					} `json:"webhook"`
+				} `json:"authorizers"`
+			}
+			if err := yaml.Unmarshal([]byte(out), &parsed); err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, a := range parsed.Authorizers {
+				names = append(names, a.Name)
+			}
+			if want := []string{"node", authorizerName, "rbac"}; !slices.Equal(names, want) {
+				t.Fatalf("authorizers = %v, want %v", names, want)
+			}
+
+			webhook := parsed.Authorizers[1].Webhook
+			if webhook.FailurePolicy != string(policy) {
+				t.Errorf("failure policy = %q, want %q", webhook.FailurePolicy, policy)
+			}
+			if len(webhook.MatchConditions) != 1 || !strings.Contains(webhook.MatchConditions[0].Expression, "request.extra") {
+				t.Errorf("match conditions = %v, want restriction to users with tenant", webhook.MatchConditions)
+			}
+
+			again, err := withAuthorizationWebhook(out, policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again != out {
+				t.Error("webhook was added twice")
+			}
+		})
+	}
+}
+
+func TestEnsureAuthorizationLegacy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   config.AuthorizationFailurePolicy
+		wantErr  bool
+		wantMode string
+	}{
+		{
+			name:    "deny requires structured authorization",
+			policy:  config.AuthorizationFailurePolicyDeny,
+			wantErr: true,
+		},
+		{
+			name:     "explicit no opinion",
+			policy:   config.AuthorizationFailurePolicyNoOpinion,
+			wantMode: authorizationModeFlag + "Node,Webhook,RBAC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &ensurer{config: config.ControllerConfiguration{Authorization: &config.Authorization{Enabled: true, FailurePolicy: tt.policy}}}
+			c := &corev1.Container{Name: "kube-apiserver", Command: []string{"kube-apiserver", authorizationModeFlag + "Node,RBAC"}}
+
+			err := e.ensureAuthorization(t.Context(), testNamespace, c, &corev1.PodTemplateSpec{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ensureAuthorization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Contains(c.Command, tt.wantMode) {
+				t.Errorf("command = %v, want %s", c.Command, tt.wantMode)
+			}
+		})
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/controller"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	gcontext "github.com/gardener/gardener/extensions/pkg/webhook/context"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
 	"github.com/gardener/gardener/extensions/pkg/webhook/controlplane/genericmutator"
 	"github.com/go-logr/logr"
@@ -19,31 +24,114 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	configlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 	configv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/client-go/tools/record"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+const (
+	eventReasonKubeAPIServerMutated        = "KubeAPIServerMutated"
+	eventReasonKubeAPIServerMutationFailed = "KubeAPIServerMutationFailed"
 )
 
 // NewEnsurer creates a new controlplane ensurer.
-func NewEnsurer(mgr manager.Manager, logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(mgr manager.Manager, config config.ControllerConfiguration, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		client: mgr.GetClient(),
-		logger: logger.WithName("fits-authn-controlplane-ensurer"),
+		client:   mgr.GetClient(),
+		decoder:  serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
+		recorder: mgr.GetEventRecorderFor("fits-authn-controlplane-ensurer"),
+		config:   config,
+		logger:   logger.WithName("fits-authn-controlplane-ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	client client.Client
-	logger logr.Logger
+	client   client.Client
+	decoder  runtime.Decoder
+	recorder record.EventRecorder
+	config   config.ControllerConfiguration
+	logger   logr.Logger
 }
 
 // EnsureKubeAPIServerDeployment ensures that the kube-apiserver deployment conforms to the provider requirements.
-func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, _ gcontext.GardenContext, new, _ *appsv1.Deployment) error {
+func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, gctx gcontext.GardenContext, new, _ *appsv1.Deployment) error {
+	result, err := e.ensureKubeAPIServerDeployment(ctx, gctx, new)
+	if err != nil {
+		result = mutationResultError
+	}
+	kubeAPIServerMutations.WithLabelValues(result).Inc()
+
+	switch result {
+	case mutationResultMutated:
+		e.recordEvent(ctx, new.Namespace, corev1.EventTypeNormal, eventReasonKubeAPIServerMutated, "Configured kube-apiserver to use the authn webhook")
+	case mutationResultError:
+		e.recordEvent(ctx, new.Namespace, corev1.EventTypeWarning, eventReasonKubeAPIServerMutationFailed, fmt.Sprintf("Failed to configure kube-apiserver: %v", err))
+	}
+
+	return err
+}
+
+// recordEvent records an event at the Extension resource in the given namespace. Events are best effort,
+// so a missing Extension resource does not fail the mutation.
+func (e *ensurer) recordEvent(ctx context.Context, namespace, eventType, reason, message string) {
+	ex := &extensionsv1alpha1.Extension{}
+	if err := e.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: controller.Type}, ex); err != nil {
+		if !apierrors.IsNotFound(err) {
+			e.logger.Error(err, "unable to get extension resource for recording event", "namespace", namespace)
+		}
+		return
+	}
+
+	e.recorder.Event(ex, eventType, reason, message)
+}
+
+func (e *ensurer) ensureKubeAPIServerDeployment(ctx context.Context, gctx gcontext.GardenContext, new *appsv1.Deployment) (string, error) {
 	namespace := new.Namespace
 
-	kubeconfig, err := webhookKubeconfig(namespace)
+	cluster, err := gctx.GetCluster(ctx)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	authnConfig, err := e.authnConfig(cluster)
+	if err != nil {
+		return "", err
+	}
+
+	if !controller.TokenWebhookConfigured(authnConfig, &e.config) {
+		e.logger.Info("token webhook is suspended, not configuring kube-apiserver", "namespace", namespace)
+		return mutationResultSkipped, nil
+	}
+
+	// the token webhook is combined with the native authenticators of the shoot by the kube-apiserver, the same
+	// validation as in the actuator rejects conflicts that arise between two reconciliations of the extension
+	authnConfig.Issuer, err = e.issuer(ctx, namespace, authnConfig)
+	if err != nil {
+		return "", err
+	}
+	native, err := controller.NativeAuthenticationOf(ctx, e.client, cluster, new)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine native authentication of the shoot: %w", err)
+	}
+	if err := controller.ValidateShootAuthentication(authnConfig, &e.config, native); err != nil {
+		return "", err
+	}
+	tokenWebhook := controller.TokenWebhookSettingsFor(e.config.TokenWebhook, authnConfig.TokenWebhook)
+
+	kubeconfig, err := webhookKubeconfig(namespace, "authenticate")
+	if err != nil {
+		return "", err
+	}
+
+	authorization := e.config.Authorization != nil && e.config.Authorization.Enabled
+	var authzKubeconfig []byte
+	if authorization {
+		authzKubeconfig, err = webhookKubeconfig(namespace, "authorize")
+		if err != nil {
+			return "", err
+		}
 	}
 
 	e.logger.Info("ensuring webhook configmap")
@@ -57,22 +145,33 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, _ gcontext.
 			"authn-webhook-config.json": string(kubeconfig),
 		},
 	}
+	if authorization {
+		cm.Data[authzWebhookConfigKey] = string(authzKubeconfig)
+	}
 
 	err = e.client.Get(ctx, client.ObjectKeyFromObject(cm), cm)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
-			return err
+			return "", err
 		}
 		err := e.client.Create(ctx, cm)
 		if err != nil {
-			return err
+			return "", err
 		}
 	} else {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
 		cm.Data["authn-webhook-config.json"] = string(kubeconfig)
+		if authorization {
+			cm.Data[authzWebhookConfigKey] = string(authzKubeconfig)
+		} else {
+			delete(cm.Data, authzWebhookConfigKey)
+		}
 
 		err := e.client.Update(ctx, cm)
 		if err != nil {
-			return err
+			return "", err
 		}
 	}
 
@@ -81,20 +180,74 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, _ gcontext.
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver"); c != nil {
 		e.logger.Info("ensuring kube-apiserver deployment")
 
-		ensureKubeAPIServerCommandLineArgs(c)
+		ensureKubeAPIServerCommandLineArgs(c, tokenWebhook)
 		ensureVolumeMounts(c)
 		ensureVolumes(ps)
+
+		if authorization {
+			if err := e.ensureAuthorization(ctx, namespace, c, template); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if tw := e.config.TokenWebhook; tw != nil && tw.ReadinessTimeout != nil && tw.ReadinessTimeout.Duration > 0 {
+		if err := ensureWaitForWebhook(ps, namespace, tw.ReadinessTimeout.Duration); err != nil {
+			return "", err
+		}
 	}
 
 	template.Labels["networking.resources.gardener.cloud/to-kube-jwt-authn-webhook-tcp-8443"] = "allowed"
 
-	return nil
+	return mutationResultMutated, nil
+}
+
+// authnConfig returns the provider config of the extension in the given cluster.
+func (e *ensurer) authnConfig(cluster *extensionscontroller.Cluster) (*authn.AuthnConfig, error) {
+	authnConfig := &authn.AuthnConfig{}
+
+	for _, ext := range cluster.Shoot.Spec.Extensions {
+		if ext.Type != controller.Type || ext.ProviderConfig == nil {
+			continue
+		}
+
+		if _, _, err := e.decoder.Decode(ext.ProviderConfig.Raw, nil, authnConfig); err != nil {
+			return nil, fmt.Errorf("failed to decode provider config: %w", err)
+		}
+	}
+
+	return authnConfig, nil
 }
 
-func webhookKubeconfig(namespace string) ([]byte, error) {
+// issuer returns the issuer of the token webhook. It is taken from the provider status of the Extension resource,
+// because the issuer can be defaulted by the auth profile of the shoot. Before the first reconciliation of the
+// extension, the issuer of the provider config or the default profile is used.
+func (e *ensurer) issuer(ctx context.Context, namespace string, authnConfig *authn.AuthnConfig) (string, error) {
+	ex := &extensionsv1alpha1.Extension{}
+	if err := e.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: controller.Type}, ex); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("unable to get extension resource: %w", err)
+		}
+	} else if ex.Status.ProviderStatus != nil && len(ex.Status.ProviderStatus.Raw) > 0 {
+		status := &authn.AuthnStatus{}
+		if _, _, err := e.decoder.Decode(ex.Status.ProviderStatus.Raw, nil, status); err != nil {
+			return "", fmt.Errorf("unable to decode provider status: %w", err)
+		}
+		if len(status.Issuers) > 0 {
+			return status.Issuers[0], nil
+		}
+	}
+
+	if authnConfig.Issuer != "" {
+		return authnConfig.Issuer, nil
+	}
+	return e.config.Auth.Issuer, nil
+}
+
+func webhookKubeconfig(namespace, path string) ([]byte, error) {
 	var (
 		contextName = "kube-jwt-authn-webhook"
-		url         = fmt.Sprintf("http://kube-jwt-authn-webhook.%s.svc.cluster.local:8443/authenticate", namespace)
+		url         = controller.WebhookURL(namespace, path)
 	)
 
 	config := &configv1.Config{
@@ -157,15 +310,21 @@ func ensureVolumes(ps *corev1.PodSpec) {
 	ps.Volumes = extensionswebhook.EnsureVolumeWithName(ps.Volumes, authnWebhookConfigVolume)
 }
 
-func ensureKubeAPIServerCommandLineArgs(c *corev1.Container) {
+func ensureKubeAPIServerCommandLineArgs(c *corev1.Container, tokenWebhook controller.TokenWebhookSettings) {
+	// a token webhook configured by someone else is rejected before, the kube-apiserver only supports a single one
 	c.Command = extensionswebhook.EnsureStringWithPrefix(
 		c.Command,
 		"--authentication-token-webhook-config-file=",
-		"/etc/webhook/config/authn-webhook-config.json",
+		controller.TokenWebhookConfigFile,
 	)
 	c.Command = extensionswebhook.EnsureStringWithPrefix(
 		c.Command,
 		"--authentication-token-webhook-version=",
 		"v1",
 	)
+	c.Command = extensionswebhook.EnsureStringWithPrefix(
+		c.Command,
+		"--authentication-token-webhook-cache-ttl=",
+		tokenWebhook.CacheTTL.String(),
+	)
 }
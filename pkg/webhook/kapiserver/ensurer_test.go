@@ -0,0 +1,226 @@
+package kapiserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/authn/install"
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
+	"github.com/fi-ts/gardener-extension-authn/pkg/controller"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gcontext "github.com/gardener/gardener/extensions/pkg/webhook/context"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	testIssuer    = "https://issuer.example.com"
+	testNamespace = "shoot--project--shoot"
+)
+
+func TestEnsureKubeAPIServerDeploymentNativeAuthentication(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, extensionsv1alpha1.AddToScheme, install.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	authenticationConfig := func(name, issuer string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Data:       map[string]string{"config.yaml": "jwt:\n- issuer:\n    url: " + issuer + "\n"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		kubeAPI    *gardencorev1beta1.KubeAPIServerConfig
+		command    []string
+		configMap  string
+		issuer     string
+		status     string
+		wantResult string
+	}{
+		{
+			name:       "no native authentication",
+			wantResult: mutationResultMutated,
+		},
+		{
+			name: "oidc config with another issuer",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To("https://other.example.com"), GroupsPrefix: ptr.To("oidc:")},
+			},
+			wantResult: mutationResultMutated,
+		},
+		{
+			name: "oidc config with the issuer of the provider config",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To(testIssuer), GroupsPrefix: ptr.To("oidc:")},
+			},
+			issuer:     testIssuer,
+			wantResult: mutationResultError,
+		},
+		{
+			name: "oidc config with the issuer of the default profile",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To(testIssuer), GroupsPrefix: ptr.To("oidc:")},
+			},
+			wantResult: mutationResultError,
+		},
+		{
+			name: "oidc config with the issuer of the provider status",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				OIDCConfig: &gardencorev1beta1.OIDCConfig{IssuerURL: ptr.To("https://profile.example.com"), GroupsPrefix: ptr.To("oidc:")},
+			},
+			status:     "https://profile.example.com",
+			wantResult: mutationResultError,
+		},
+		{
+			name: "structured authentication with another issuer",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				StructuredAuthentication: &gardencorev1beta1.StructuredAuthentication{ConfigMapName: "auth"},
+			},
+			configMap:  "auth-other",
+			wantResult: mutationResultMutated,
+		},
+		{
+			name: "structured authentication with the issuer of the extension",
+			kubeAPI: &gardencorev1beta1.KubeAPIServerConfig{
+				StructuredAuthentication: &gardencorev1beta1.StructuredAuthentication{ConfigMapName: "auth"},
+			},
+			configMap:  "auth-same",
+			wantResult: mutationResultError,
+		},
+		{
+			name:       "foreign token webhook flag",
+			command:    []string{"--authentication-token-webhook-config-file=/etc/other/webhook.json"},
+			wantResult: mutationResultError,
+		},
+	}
+
+	modes := []struct {
+		suffix     string
+		suspended  bool
+		breakGlass bool
+	}{
+		{suffix: ""},
+		{suffix: " while suspended", suspended: true},
+		{suffix: " while suspended with break-glass", suspended: true, breakGlass: true},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range modes {
+			suspended := mode.suspended
+
+			t.Run(tt.name+mode.suffix, func(t *testing.T) {
+				providerConfig := `{"apiVersion":"authn.fits.extensions.gardener.cloud/v1alpha1","kind":"AuthnConfig"`
+				if tt.issuer != "" {
+					providerConfig += `,"issuer":"` + tt.issuer + `"`
+				}
+				if suspended {
+					providerConfig += `,"suspended":true`
+				}
+				providerConfig += "}"
+
+				cluster := &extensionscontroller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Kubernetes: gardencorev1beta1.Kubernetes{KubeAPIServer: tt.kubeAPI},
+							Extensions: []gardencorev1beta1.Extension{{
+								Type:           controller.Type,
+								ProviderConfig: &runtime.RawExtension{Raw: []byte(providerConfig)},
+							}},
+						},
+					},
+				}
+
+				deployment := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver", Namespace: testNamespace},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "kube-apiserver", Command: slices.Concat([]string{"kube-apiserver"}, tt.command)}},
+							},
+						},
+					},
+				}
+				if tt.configMap != "" {
+					deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+						Name: "authentication-config",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: tt.configMap}},
+						},
+					}}
+				}
+
+				builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					authenticationConfig("auth-other", "https://other.example.com"),
+					authenticationConfig("auth-same", testIssuer),
+				)
+				if tt.status != "" {
+					builder = builder.WithObjects(&extensionsv1alpha1.Extension{
+						ObjectMeta: metav1.ObjectMeta{Name: controller.Type, Namespace: testNamespace},
+						Status: extensionsv1alpha1.ExtensionStatus{
+							DefaultStatus: extensionsv1alpha1.DefaultStatus{
+								ProviderStatus: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"authn.fits.extensions.gardener.cloud/v1alpha1","kind":"AuthnStatus","issuers":["` + tt.status + `"]}`)},
+							},
+						},
+					})
+				}
+
+				cfg := config.ControllerConfiguration{Auth: config.Auth{Issuer: testIssuer}}
+				if mode.breakGlass {
+					cfg.BreakGlass = &config.BreakGlass{GardenNamespace: "break-glass"}
+				}
+
+				e := &ensurer{
+					client:   builder.Build(),
+					decoder:  serializer.NewCodecFactory(scheme).UniversalDecoder(),
+					recorder: record.NewFakeRecorder(10),
+					config:   cfg,
+					logger:   logr.Discard(),
+				}
+
+				result, err := e.ensureKubeAPIServerDeployment(ctx, gcontext.NewInternalGardenContext(cluster), deployment)
+
+				want := tt.wantResult
+				if suspended && !mode.breakGlass {
+					want = mutationResultSkipped
+				}
+				switch {
+				case want == mutationResultError:
+					if err == nil {
+						t.Fatalf("ensureKubeAPIServerDeployment() = %q, want error", result)
+					}
+					return
+				case err != nil:
+					t.Fatalf("ensureKubeAPIServerDeployment() error = %v", err)
+				case result != want:
+					t.Fatalf("ensureKubeAPIServerDeployment() = %q, want %q", result, want)
+				}
+
+				command := deployment.Spec.Template.Spec.Containers[0].Command
+				configured := slices.Contains(command, "--authentication-token-webhook-config-file="+controller.TokenWebhookConfigFile)
+				if configured != (want == mutationResultMutated) {
+					t.Errorf("token webhook configured = %v, want %v (command %v)", configured, want == mutationResultMutated, command)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package kapiserver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	mutationResultMutated = "mutated"
+	mutationResultSkipped = "skipped"
+	mutationResultError   = "error"
+)
+
+var kubeAPIServerMutations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "fits_authn_extension_kube_apiserver_mutations_total",
+	Help: "Number of kube-apiserver deployment mutations by result.",
+}, []string{"result"})
+
+// RegisterMetrics registers the metrics of the kube-apiserver webhook at the given registerer.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(kubeAPIServerMutations)
+}
@@ -0,0 +1,60 @@
+package kapiserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fi-ts/gardener-extension-authn/pkg/imagevector"
+	"github.com/fi-ts/gardener-extension-authn/pkg/version"
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	"k8s.io/utils/ptr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	waitForWebhookContainerName = "wait-for-authn-webhook"
+	webhookServiceHost          = "kube-jwt-authn-webhook"
+	webhookServicePort          = 8443
+)
+
+// ensureWaitForWebhook injects an init container into the kube-apiserver pod which waits until the health endpoint
+// of the token webhook responds. Without it, the kube-apiserver may come up before the webhook after a wake-up from
+// hibernation and rejects the first logins. The webhook is scaled up as soon as the hibernation of the shoot is
+// disabled, the timeout makes sure a broken webhook does not block the kube-apiserver forever. The wait is
+// implemented by the authenticator built from this repository, so no shell or network tools are needed.
+func ensureWaitForWebhook(ps *corev1.PodSpec, namespace string, timeout time.Duration) error {
+	image, err := imagevector.ImageVector().FindImage(imagevector.ImageNameFitsAuthnWebhook)
+	if err != nil {
+		return fmt.Errorf("failed to find %s image: %w", imagevector.ImageNameFitsAuthnWebhook, err)
+	}
+	image.WithOptionalTag(version.Version)
+
+	ps.InitContainers = extensionswebhook.EnsureContainerWithName(ps.InitContainers, corev1.Container{
+		Name:  waitForWebhookContainerName,
+		Image: image.String(),
+		Command: []string{
+			"/authn-webhook",
+			"wait",
+			"--url=" + webhookHealthzURL(namespace),
+			"--timeout=" + timeout.String(),
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+		},
+	})
+
+	return nil
+}
+
+// webhookHealthzURL returns the url of the health endpoint of the token webhook service.
+func webhookHealthzURL(namespace string) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/healthz", webhookServiceHost, namespace, webhookServicePort)
+}
@@ -0,0 +1,27 @@
+package kapiserver
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEnsureWaitForWebhook(t *testing.T) {
+	ps := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "other"}, {Name: waitForWebhookContainerName, Command: []string{"sh", "-c", "nc -z"}}},
+	}
+
+	if err := ensureWaitForWebhook(ps, testNamespace, 2*time.Minute); err != nil {
+		t.Fatalf("ensureWaitForWebhook() error = %v", err)
+	}
+
+	if len(ps.InitContainers) != 2 {
+		t.Fatalf("init containers = %v, want the wait container to be replaced", ps.InitContainers)
+	}
+	want := []string{"/authn-webhook", "wait", "--url=http://kube-jwt-authn-webhook." + testNamespace + ".svc.cluster.local:8443/healthz", "--timeout=2m0s"}
+	if got := ps.InitContainers[1].Command; !slices.Equal(got, want) {
+		t.Errorf("command = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package kapiserver
 
 import (
+	"github.com/fi-ts/gardener-extension-authn/pkg/apis/config"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/extensions/pkg/webhook/controlplane/genericmutator"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
@@ -15,6 +16,15 @@ import (
 
 var logger = log.Log.WithName("fits-authn-webhook")
 
+// DefaultAddOptions are the default options for the kube-apiserver webhook.
+var DefaultAddOptions = AddOptions{}
+
+// AddOptions are options to apply when adding the kube-apiserver webhook to the manager.
+type AddOptions struct {
+	// Config contains the configuration of the extension.
+	Config config.ControllerConfiguration
+}
+
 // New returns a new mutating webhook that ensures that the kube-apiserver deployment conforms to the fits-authn requirements.
 func New(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
 	logger.Info("Adding webhook to manager")
@@ -23,7 +33,7 @@ func New(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
 
 	mutator := genericmutator.NewMutator(
 		mgr,
-		NewEnsurer(mgr, logger),
+		NewEnsurer(mgr, DefaultAddOptions.Config, logger),
 		oscutils.NewUnitSerializer(),
 		kubelet.NewConfigCodec(fciCodec),
 		fciCodec,